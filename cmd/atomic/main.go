@@ -34,7 +34,7 @@ func main() {
 	fmt.Printf("b.shape: %v\n", b.Shape())
 	fmt.Printf("b.stride: %v\n\n", b.Stride())
 
-	Wx, err := W.Mul(x)
+	Wx, err := W.MatMul(x)
 	if err != nil {
 		panic(err)
 	}
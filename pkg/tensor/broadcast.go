@@ -5,29 +5,39 @@ import (
 	"strings"
 )
 
-// BroadcastStruct represents a broadcast
+// BroadcastStruct represents a broadcast of a tensor, view or broadcast to a
+// larger shape, expanded dimensions are read with a stride of 0
 type BroadcastStruct struct {
 	broadcastShape []int
 	strides        []int
-	tensor         *TensorStruct
+	source         Operand
 }
 
 // Broadcast is the interface for a broadcast
 type Broadcast interface {
-	Shape() []int
-	Stride() []int
+	Operand
+
 	Data() []float64
 
 	String() string
 
 	GetFlat(idx int) float64
 	Get(idx []int) (float64, error)
+	At(idx ...int) (float64, error)
+	AtFlat(idx int) (float64, error)
+	Item() (float64, error)
+
+	Add(other Operand) (*TensorStruct, error)
+	Sub(other Operand) (*TensorStruct, error)
+	Mul(other Operand) (*TensorStruct, error)
+	Div(other Operand) (*TensorStruct, error)
+	DivWith(other Operand, policy DivisionPolicy) (*TensorStruct, error)
 
 	ToTensor() (*TensorStruct, error)
 }
 
-// validBroadcast checks if two tensors can be broadcasted
-func validBroadcast(broadcastShape []int, tensor *TensorStruct) error {
+// validBroadcast checks if a tensor can be broadcasted to the shape
+func validBroadcast(broadcastShape []int, tensor Operand) error {
 	// Check if broadcast shape is empty
 	if len(broadcastShape) == 0 {
 		return fmt.Errorf("cannot broadcast to a 0-dimensional shape")
@@ -48,20 +58,18 @@ func validBroadcast(broadcastShape []int, tensor *TensorStruct) error {
 	// Get the tensor's shape
 	tensorShape := tensor.Shape()
 
+	// Check that the tensor does not have more dimensions than the target
+	if len(tensorShape) > len(broadcastShape) {
+		return fmt.Errorf("cannot broadcast shape %v to lower rank shape %v", tensorShape, broadcastShape)
+	}
+
 	// Check each dimension from right to left
-	for i := 0; i < len(broadcastShape) || i < len(tensorShape); i++ {
-		// Get the dimensions, defaulting to 1 if out of bounds
-		targetDim := 1
-		if i < len(broadcastShape) {
-			targetDim = broadcastShape[len(broadcastShape)-1-i]
-		}
-		sourceDim := 1
-		if i < len(tensorShape) {
-			sourceDim = tensorShape[len(tensorShape)-1-i]
-		}
+	for i := range tensorShape {
+		targetDim := broadcastShape[len(broadcastShape)-1-i]
+		sourceDim := tensorShape[len(tensorShape)-1-i]
 
-		// Check if dimensions are compatible
-		if sourceDim != targetDim && sourceDim != 1 && targetDim != 1 {
+		// Check if the source dimension matches or can be expanded
+		if sourceDim != targetDim && sourceDim != 1 {
 			return fmt.Errorf("incompatible shapes for broadcasting: %v and %v", tensorShape, broadcastShape)
 		}
 	}
@@ -70,68 +78,77 @@ func validBroadcast(broadcastShape []int, tensor *TensorStruct) error {
 	return nil
 }
 
-// alignShapes aligns the shapes of two tensors if possible
-func alignShapes(s1 []int, s2 []int) ([]int, error) {
-	// Initialize the aligned shape
-	alignedShape := make([]int, len(s2))
-
-	// Populate the aligned shape
-	for s2Idx := len(s2) - 1; s2Idx >= 0; s2Idx-- {
-		s1Idx := len(s2) - 1 - s2Idx
-
-		// Check if s1Idx is out of bounds
-		if s1Idx < len(s1) {
-			// If not, use the value from s1
-			alignedShape[s2Idx] = s1[s1Idx]
-		} else {
-			// If s1Idx is out of bounds, use 1
-			alignedShape[s2Idx] = 1
-		}
+// broadcastShapes computes the shape that two shapes broadcast to
+func broadcastShapes(s1 []int, s2 []int) ([]int, error) {
+	// The result has the rank of the larger shape
+	rank := len(s1)
+	if len(s2) > rank {
+		rank = len(s2)
 	}
 
-	// Return the aligned shape
-	return alignedShape, nil
-}
+	// Initialize the result shape
+	result := make([]int, rank)
 
-// computeBroadcastStrides computes the strides of a broadcast
-func computeBroadcastStrides(tensorShape []int, alignedShape []int, tensorStrides []int) []int {
-	// Initialize the broadcast strides
-	broadcastStrides := make([]int, len(alignedShape))
+	// Check each dimension from right to left
+	for i := 0; i < rank; i++ {
+		// Get the dimensions, defaulting to 1 if out of bounds
+		d1 := 1
+		if i < len(s1) {
+			d1 = s1[len(s1)-1-i]
+		}
+		d2 := 1
+		if i < len(s2) {
+			d2 = s2[len(s2)-1-i]
+		}
+
+		// Pick the non-1 dimension, or fail if both are non-1 and differ
+		switch {
+		case d1 == d2 || d2 == 1:
+			result[rank-1-i] = d1
+		case d1 == 1:
+			result[rank-1-i] = d2
+		default:
+			return nil, fmt.Errorf("incompatible shapes for broadcasting: %v and %v", s1, s2)
+		}
+	}
 
-	// Copy the tensor strides to the broadcast strides
-	copy(broadcastStrides, tensorStrides)
-	// Set the last stride to 1
-	broadcastStrides[len(broadcastStrides)-1] = 1
+	// Return the result shape
+	return result, nil
+}
 
-	// Set the broadcasted dimensions to 0
-	for i := 0; i < len(alignedShape)-len(tensorShape); i++ {
-		broadcastStrides[i] = 0
+// expandStrides computes the strides needed to read a tensor with the given
+// shape and stride as if it had the broadcast shape, the shapes are aligned
+// from the right and every missing or size 1 dimension gets a stride of 0
+func expandStrides(shape []int, stride []int, broadcastShape []int) []int {
+	// Initialize the expanded strides, missing dimensions stay 0
+	expanded := make([]int, len(broadcastShape))
+
+	// Copy the strides of the dimensions that are not broadcast
+	offset := len(broadcastShape) - len(shape)
+	for i := range shape {
+		if shape[i] != 1 {
+			expanded[offset+i] = stride[i]
+		}
 	}
 
-	// Return the broadcast strides
-	return broadcastStrides
+	// Return the expanded strides
+	return expanded
 }
 
-// NewBroadcast creates a new broadcast struct from a tensor
-func NewBroadcast(broadcastShape []int, tensor *TensorStruct) (*BroadcastStruct, error) {
+// NewBroadcast creates a new broadcast struct from a tensor, view or broadcast
+func NewBroadcast(broadcastShape []int, tensor Operand) (*BroadcastStruct, error) {
 	// Check broadcast validity
 	if err := validBroadcast(broadcastShape, tensor); err != nil {
 		return nil, err
 	}
 
-	// Align the shapes
-	alignedShape, err := alignShapes(tensor.Shape(), broadcastShape)
-	if err != nil {
-		return nil, err
-	}
-
-	// Compute strides
-	broadcastStrides := computeBroadcastStrides(tensor.Shape(), alignedShape, tensor.Stride())
+	// Compute strides, expanded dimensions get a stride of 0
+	broadcastStrides := expandStrides(tensor.Shape(), tensor.Stride(), broadcastShape)
 
 	// Return the broadcast
 	return &BroadcastStruct{
 		broadcastShape: broadcastShape,
-		tensor:         tensor,
+		source:         tensor,
 		strides:        broadcastStrides,
 	}, nil
 }
@@ -146,69 +163,69 @@ func (b *BroadcastStruct) Stride() []int {
 	return b.strides
 }
 
-// Data returns the data of the broadcast
+// Offset returns the offset of the broadcast's first element in the source's data
+func (b *BroadcastStruct) Offset() int {
+	return b.source.Offset()
+}
+
+// DType returns the dtype of the broadcast
+func (b *BroadcastStruct) DType() DType {
+	return b.source.DType()
+}
+
+// storage returns the data the broadcast reads from
+func (b *BroadcastStruct) storage() storage {
+	return b.source.storage()
+}
+
+// Data returns the data of the broadcast's source in row-major order
 func (b *BroadcastStruct) Data() []float64 {
-	return b.tensor.data
+	return toFloat64s(contiguousStorage(b.source))
+}
+
+// offset converts an index into the broadcast shape to an offset into the source's data
+func (b *BroadcastStruct) offset(idx []int) int {
+	offset := b.source.Offset()
+	for i, v := range idx {
+		offset += v * b.strides[i]
+	}
+	return offset
+}
+
+// values returns the broadcast's elements in row-major order
+func (b *BroadcastStruct) values() []float64 {
+	return toFloat64s(gather(b.storage(), b.broadcastShape, b.strides, b.Offset()))
 }
 
 // String returns a string representation of the broadcast
 func (b *BroadcastStruct) String() string {
+	values := b.values()
+	dtype := b.DType()
 	switch len(b.broadcastShape) {
 	case 0:
 		// Scalar case
-		return fmt.Sprintf("%.2f", b.tensor.data[0])
+		return formatValue(values[0], dtype)
 	case 1:
 		// 1D case
-		values := make([]string, b.broadcastShape[0])
-		for i := 0; i < b.broadcastShape[0]; i++ {
-			// If stride is 0, repeat the first value
-			idx := 0
-			if b.strides[0] != 0 {
-				idx = i * b.strides[0]
-			}
-			values[i] = fmt.Sprintf("%.2f", b.tensor.data[idx])
-		}
-		return fmt.Sprintf("[%s]", strings.Join(values, " "))
+		return fmt.Sprintf("[%s]", formatSlice(values, dtype))
 	case 2:
 		// 2D case
 		rows := make([]string, b.broadcastShape[0])
 		for i := 0; i < b.broadcastShape[0]; i++ {
-			// Calculate row offset, if stride is 0, use 0 to repeat the same row
-			rowOffset := 0
-			if b.strides[0] != 0 {
-				rowOffset = i * b.strides[0]
-			}
-
-			// Build each row
-			row := make([]string, b.broadcastShape[1])
-			for j := 0; j < b.broadcastShape[1]; j++ {
-				// Calculate column offset, if stride is 0, use 0 to repeat the same value
-				colOffset := 0
-				if b.strides[1] != 0 {
-					colOffset = j * b.strides[1]
-				}
-				row[j] = fmt.Sprintf("%.2f", b.tensor.data[rowOffset+colOffset])
-			}
-			rows[i] = "[" + strings.Join(row, " ") + "]"
+			start := i * b.broadcastShape[1]
+			end := start + b.broadcastShape[1]
+			rows[i] = "[" + formatSlice(values[start:end], dtype) + "]"
 		}
 		return "[\n " + strings.Join(rows, "\n ") + "\n]"
 	default:
-		// For higher dimensions, show shape and first few values
-		values := make([]string, b.broadcastShape[len(b.broadcastShape)-1])
-		for i := 0; i < b.broadcastShape[len(b.broadcastShape)-1]; i++ {
-			idx := 0
-			if b.strides[len(b.strides)-1] != 0 {
-				idx = i * b.strides[len(b.strides)-1]
-			}
-			values[i] = fmt.Sprintf("%.2f", b.tensor.data[idx])
-		}
-		return fmt.Sprintf("Broadcast(shape=%v, data=[%s])", b.broadcastShape, strings.Join(values, " "))
+		// For higher dimensions, show shape and values
+		return fmt.Sprintf("Broadcast(shape=%v, data=[%s])", b.broadcastShape, formatSlice(values, dtype))
 	}
 }
 
-// GetFlat returns the value at the given flat index
+// GetFlat returns the value at the given flat index into the broadcast shape
 func (b *BroadcastStruct) GetFlat(idx int) float64 {
-	return b.tensor.data[idx%len(b.tensor.data)]
+	return b.storage().get(b.offset(unravelIndex(idx, b.broadcastShape)))
 }
 
 // Get returns the value at the given index
@@ -225,64 +242,60 @@ func (b *BroadcastStruct) Get(idx []int) (float64, error) {
 		}
 	}
 
-	// Convert broadcast index to tensor index
-	tensorIdx := make([]int, len(b.tensor.Shape()))
-	for i := range tensorIdx {
-		if i < len(idx) {
-			// Get corresponding tensor dimension size
-			tensorDim := b.tensor.Shape()[i]
-			if tensorDim == 1 {
-				// Broadcasting case: tensor dim is 1
-				tensorIdx[i] = 0
-			} else {
-				// Normal case: use index directly
-				tensorIdx[i] = idx[i]
-			}
-		} else {
-			// If index is out of bounds, set to 0
-			tensorIdx[i] = 0
-		}
-	}
+	// Return value
+	return b.storage().get(b.offset(idx)), nil
+}
 
-	// Convert tensor index to flat index
-	flatIndex := 0
-	for i, v := range tensorIdx {
-		flatIndex += v * b.tensor.Stride()[i]
+// At returns the element at the given index of the broadcast
+func (b *BroadcastStruct) At(idx ...int) (float64, error) {
+	return b.Get(idx)
+}
+
+// AtFlat returns the element at the given row-major flat index of the broadcast
+func (b *BroadcastStruct) AtFlat(idx int) (float64, error) {
+	// Convert the flat index to an offset
+	offset, err := flatOffsetOf(b.broadcastShape, b.strides, idx)
+	if err != nil {
+		return 0, err
 	}
 
-	// Return value
-	return b.tensor.Data()[flatIndex], nil
+	// Return the element
+	return b.storage().get(b.Offset() + offset), nil
 }
 
-// ToTensor returns creates a new tensor from the broadcast
-func (b *BroadcastStruct) ToTensor() (*TensorStruct, error) {
-	// Calculate total size of broadcasted tensor
-	size := 1
-	for _, dim := range b.broadcastShape {
-		size *= dim
+// Item returns the only element of a broadcast holding exactly one element
+func (b *BroadcastStruct) Item() (float64, error) {
+	// Check that there is exactly one element
+	if err := validItem(b.broadcastShape); err != nil {
+		return 0, err
 	}
 
-	// Create result data
-	data := make([]float64, size)
-
-	// Fill data using broadcast rules
-	for i := 0; i < size; i++ {
-		// Convert flat index to multi-dimensional indices
-		indices := make([]int, len(b.broadcastShape))
-		remaining := i
-		for j := len(b.broadcastShape) - 1; j >= 0; j-- {
-			indices[j] = remaining % b.broadcastShape[j]
-			remaining = remaining / b.broadcastShape[j]
-		}
+	// Return the element
+	return b.storage().get(b.Offset()), nil
+}
 
-		// Get value using Get method which handles broadcasting
-		val, err := b.Get(indices)
-		if err != nil {
-			return nil, fmt.Errorf("error getting value at index %v: %v", indices, err)
-		}
-		data[i] = val
-	}
+// Add adds another tensor, view or broadcast to this broadcast
+func (b *BroadcastStruct) Add(other Operand) (*TensorStruct, error) {
+	return add(b, other)
+}
 
-	// Return new tensor
-	return NewTensor(b.broadcastShape, data)
+// Sub subtracts another tensor, view or broadcast from this broadcast
+func (b *BroadcastStruct) Sub(other Operand) (*TensorStruct, error) {
+	return sub(b, other)
+}
+
+// Mul multiplies this broadcast by another tensor, view or broadcast
+func (b *BroadcastStruct) Mul(other Operand) (*TensorStruct, error) {
+	return mul(b, other)
+}
+
+// Div divides this broadcast by another tensor, view or broadcast, dividing by zero
+// follows the current division policy
+func (b *BroadcastStruct) Div(other Operand) (*TensorStruct, error) {
+	return div(b, other)
+}
+
+// ToTensor returns creates a new tensor from the broadcast
+func (b *BroadcastStruct) ToTensor() (*TensorStruct, error) {
+	return newTensor(b.broadcastShape, gather(b.storage(), b.broadcastShape, b.strides, b.Offset())), nil
 }
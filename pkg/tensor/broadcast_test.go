@@ -41,6 +41,20 @@ func TestValidBroadcast(t *testing.T) {
 			data:          []float64{1, 2, 3, 4, 5, 6, 7, 8},
 			wantErr:       true,
 		},
+		{
+			name:           "invalid broadcast - shrinking dimension",
+			broadcastShape: []int{1, 3},
+			tensorShape:    []int{2, 3},
+			data:          []float64{1, 2, 3, 4, 5, 6},
+			wantErr:       true,
+		},
+		{
+			name:           "invalid broadcast - lower rank target",
+			broadcastShape: []int{3},
+			tensorShape:    []int{1, 3},
+			data:          []float64{1, 2, 3},
+			wantErr:       true,
+		},
 		{
 			name:           "invalid broadcast - empty shape",
 			broadcastShape: []int{},
@@ -64,6 +78,36 @@ func TestValidBroadcast(t *testing.T) {
 	}
 }
 
+func TestBroadcastShapes(t *testing.T) {
+	tests := []struct {
+		name    string
+		s1, s2  []int
+		want    []int
+		wantErr bool
+	}{
+		{"same shape", []int{2, 3}, []int{2, 3}, []int{2, 3}, false},
+		{"scalar", []int{}, []int{2, 3}, []int{2, 3}, false},
+		{"lower rank", []int{3}, []int{2, 3}, []int{2, 3}, false},
+		{"column and row", []int{3, 1}, []int{1, 4}, []int{3, 4}, false},
+		{"interior", []int{2, 1, 4}, []int{3, 1}, []int{2, 3, 4}, false},
+		{"incompatible", []int{2, 3}, []int{2, 4}, nil, true},
+		{"misaligned", []int{3, 2}, []int{2, 3}, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := broadcastShapes(tt.s1, tt.s2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("broadcastShapes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("broadcastShapes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewBroadcast(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -147,6 +191,33 @@ func TestBroadcastGet(t *testing.T) {
 			want:          2,
 			wantErr:       false,
 		},
+		{
+			name:           "get from column broadcast",
+			broadcastShape: []int{2, 3},
+			tensorShape:    []int{2, 1},
+			data:          []float64{1, 2},
+			indices:       []int{1, 2},
+			want:          2,
+			wantErr:       false,
+		},
+		{
+			name:           "get from lower rank broadcast",
+			broadcastShape: []int{2, 3},
+			tensorShape:    []int{3},
+			data:          []float64{1, 2, 3},
+			indices:       []int{1, 2},
+			want:          3,
+			wantErr:       false,
+		},
+		{
+			name:           "get from interior broadcast",
+			broadcastShape: []int{2, 3, 2},
+			tensorShape:    []int{2, 1, 2},
+			data:          []float64{1, 2, 3, 4},
+			indices:       []int{1, 2, 0},
+			want:          3,
+			wantErr:       false,
+		},
 		{
 			name:           "invalid indices",
 			broadcastShape: []int{2, 2},
@@ -206,6 +277,30 @@ func TestBroadcastToTensor(t *testing.T) {
 			wantData:      []float64{1, 2, 1, 2},
 			wantErr:       false,
 		},
+		{
+			name:           "column to 2x3",
+			broadcastShape: []int{2, 3},
+			tensorShape:    []int{2, 1},
+			data:          []float64{1, 2},
+			wantData:      []float64{1, 1, 1, 2, 2, 2},
+			wantErr:       false,
+		},
+		{
+			name:           "interior to 2x3x2",
+			broadcastShape: []int{2, 3, 2},
+			tensorShape:    []int{2, 1, 2},
+			data:          []float64{1, 2, 3, 4},
+			wantData:      []float64{1, 2, 1, 2, 1, 2, 3, 4, 3, 4, 3, 4},
+			wantErr:       false,
+		},
+		{
+			name:           "trailing to 2x2x3",
+			broadcastShape: []int{2, 2, 3},
+			tensorShape:    []int{2, 2, 1},
+			data:          []float64{1, 2, 3, 4},
+			wantData:      []float64{1, 1, 1, 2, 2, 2, 3, 3, 3, 4, 4, 4},
+			wantErr:       false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -232,3 +327,64 @@ func TestBroadcastToTensor(t *testing.T) {
 		})
 	}
 }
+
+func TestBroadcastGetFlat(t *testing.T) {
+	tensor := mustNewTensor(t, []int{2, 1}, []float64{1, 2})
+	broadcast, err := NewBroadcast([]int{2, 3}, tensor)
+	if err != nil {
+		t.Fatalf("Failed to create broadcast: %v", err)
+	}
+
+	checkEqual(t, "Stride", []int{1, 0}, broadcast.Stride())
+
+	want := []float64{1, 1, 1, 2, 2, 2}
+	for i, v := range want {
+		if got := broadcast.GetFlat(i); got != v {
+			t.Errorf("Broadcast.GetFlat(%d) = %v, want %v", i, got, v)
+		}
+	}
+}
+
+func TestBroadcastString(t *testing.T) {
+	tensor := mustNewTensor(t, []int{2, 1}, []float64{1, 2})
+	broadcast, err := NewBroadcast([]int{2, 3}, tensor)
+	if err != nil {
+		t.Fatalf("Failed to create broadcast: %v", err)
+	}
+
+	want := "[\n [1.00 1.00 1.00]\n [2.00 2.00 2.00]\n]"
+	if got := broadcast.String(); got != want {
+		t.Errorf("Broadcast.String() = %q, want %q", got, want)
+	}
+}
+
+func TestBroadcastAt(t *testing.T) {
+	tensor := mustNewTensor(t, []int{3}, []float64{1, 2, 3})
+	broadcast, err := NewBroadcast([]int{2, 3}, tensor)
+	if err != nil {
+		t.Fatalf("Failed to create broadcast: %v", err)
+	}
+
+	got, err := broadcast.At(1, 2)
+	if err != nil || got != 3 {
+		t.Errorf("Broadcast.At() = %v, %v, want 3", got, err)
+	}
+
+	got, err = broadcast.AtFlat(4)
+	if err != nil || got != 2 {
+		t.Errorf("Broadcast.AtFlat() = %v, %v, want 2", got, err)
+	}
+
+	if _, err := broadcast.AtFlat(6); err == nil {
+		t.Error("Broadcast.AtFlat() expected out of bounds error")
+	}
+
+	scalar, err := NewBroadcast([]int{1, 1}, NewScalar(4))
+	if err != nil {
+		t.Fatalf("Failed to create broadcast: %v", err)
+	}
+	got, err = scalar.Item()
+	if err != nil || got != 4 {
+		t.Errorf("Broadcast.Item() = %v, %v, want 4", got, err)
+	}
+}
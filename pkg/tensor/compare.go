@@ -0,0 +1,251 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+)
+
+// boolValue converts a boolean to the value stored for it
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// compare applies a comparison to every pair of elements of a and b,
+// broadcasting both, and returns a Bool mask of the results
+func compare(a Operand, b Operand, cmp func(x, y float64) bool) (*TensorStruct, error) {
+	return elementwise(a, b, Bool, floatOp(func(x, y float64) (float64, error) {
+		return boolValue(cmp(x, y)), nil
+	}))
+}
+
+// broadcastAll computes the shape every operand broadcasts to, along with
+// the strides that read each operand at that shape
+func broadcastAll(operands ...Operand) ([]int, [][]int, error) {
+	// Compute the common shape
+	shape := []int{}
+	for _, x := range operands {
+		var err error
+		shape, err = broadcastShapes(shape, x.Shape())
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Compute the strides of each operand
+	strides := make([][]int, len(operands))
+	for i, x := range operands {
+		strides[i] = expandStrides(x.Shape(), x.Stride(), shape)
+	}
+
+	// Return the shape and strides
+	return shape, strides, nil
+}
+
+// offsetAt returns the offset of the element of x at idx, using the given strides
+func offsetAt(x Operand, stride []int, idx []int) int {
+	offset := x.Offset()
+	for i, v := range idx {
+		offset += v * stride[i]
+	}
+	return offset
+}
+
+// where selects elements from a where cond is non-zero and from b elsewhere,
+// broadcasting all three, storing the result with the given dtype
+func where(cond Operand, a Operand, b Operand, dtype DType) (*TensorStruct, error) {
+	// Broadcast the operands
+	shape, strides, err := broadcastAll(cond, a, b)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select every element
+	result := newStorage(dtype, shapeSize(shape))
+	idx := make([]int, len(shape))
+	for i := 0; i < result.len(); i++ {
+		if cond.storage().get(offsetAt(cond, strides[0], idx)) != 0 {
+			copyElement(result, i, a.storage(), offsetAt(a, strides[1], idx))
+		} else {
+			copyElement(result, i, b.storage(), offsetAt(b, strides[2], idx))
+		}
+		nextIndex(idx, shape)
+	}
+
+	// Return the result
+	return newTensor(shape, result), nil
+}
+
+// Where returns the elements of a where cond is non-zero and the elements of
+// b elsewhere, broadcasting all three to a common shape
+func Where(cond Operand, a Operand, b Operand) (*TensorStruct, error) {
+	return where(cond, a, b, promoteTypes(a.DType(), b.DType()))
+}
+
+// maskedFill replaces the elements of x where mask is non-zero with value,
+// the mask must broadcast to the shape of x
+func maskedFill(x Operand, mask Operand, value float64) (*TensorStruct, error) {
+	// Check the mask broadcasts to the shape of x without expanding x
+	shape, err := broadcastShapes(x.Shape(), mask.Shape())
+	if err != nil || !slices.Equal(shape, x.Shape()) {
+		return nil, fmt.Errorf("mask shape %v does not broadcast to %v", mask.Shape(), x.Shape())
+	}
+
+	// Fill the masked elements
+	return where(mask, NewScalar(value), x, x.DType())
+}
+
+// maskedSelect returns the elements of x where mask is non-zero as a 1D
+// tensor, broadcasting x and mask to a common shape
+func maskedSelect(x Operand, mask Operand) (*TensorStruct, error) {
+	// Broadcast the operands
+	shape, strides, err := broadcastAll(x, mask)
+	if err != nil {
+		return nil, err
+	}
+
+	// Collect the offsets of the selected elements
+	offsets := []int{}
+	idx := make([]int, len(shape))
+	for i := 0; i < shapeSize(shape); i++ {
+		if mask.storage().get(offsetAt(mask, strides[1], idx)) != 0 {
+			offsets = append(offsets, offsetAt(x, strides[0], idx))
+		}
+		nextIndex(idx, shape)
+	}
+
+	// Copy the selected elements
+	result := newStorage(x.DType(), len(offsets))
+	for i, offset := range offsets {
+		copyElement(result, i, x.storage(), offset)
+	}
+
+	// Return the result
+	return newTensor([]int{len(offsets)}, result), nil
+}
+
+// Eq returns a Bool mask of where the tensor is equal to other, broadcasting both
+func (t *TensorStruct) Eq(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x == y })
+}
+
+// Ne returns a Bool mask of where the tensor is not equal to other, broadcasting both
+func (t *TensorStruct) Ne(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x != y })
+}
+
+// Lt returns a Bool mask of where the tensor is less than other, broadcasting both
+func (t *TensorStruct) Lt(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x < y })
+}
+
+// Le returns a Bool mask of where the tensor is less than or equal to other, broadcasting both
+func (t *TensorStruct) Le(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x <= y })
+}
+
+// Gt returns a Bool mask of where the tensor is greater than other, broadcasting both
+func (t *TensorStruct) Gt(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x > y })
+}
+
+// Ge returns a Bool mask of where the tensor is greater than or equal to other, broadcasting both
+func (t *TensorStruct) Ge(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x >= y })
+}
+
+// LogicalAnd returns a Bool mask of where both the tensor and other are non-zero
+func (t *TensorStruct) LogicalAnd(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x != 0 && y != 0 })
+}
+
+// LogicalOr returns a Bool mask of where either the tensor or other is non-zero
+func (t *TensorStruct) LogicalOr(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return x != 0 || y != 0 })
+}
+
+// LogicalXor returns a Bool mask of where exactly one of the tensor and other is non-zero
+func (t *TensorStruct) LogicalXor(other Operand) (*TensorStruct, error) {
+	return compare(t, other, func(x, y float64) bool { return (x != 0) != (y != 0) })
+}
+
+// LogicalNot returns a Bool mask of where the tensor is zero
+func (t *TensorStruct) LogicalNot() *TensorStruct {
+	return unary(t, Bool, func(x float64) float64 { return boolValue(x == 0) })
+}
+
+// MaskedFill returns a copy of the tensor with the elements where mask is
+// non-zero replaced by value, the mask must broadcast to the tensor's shape
+func (t *TensorStruct) MaskedFill(mask Operand, value float64) (*TensorStruct, error) {
+	return maskedFill(t, mask, value)
+}
+
+// MaskedSelect returns the elements of the tensor where mask is non-zero as
+// a 1D tensor, in row-major order
+func (t *TensorStruct) MaskedSelect(mask Operand) (*TensorStruct, error) {
+	return maskedSelect(t, mask)
+}
+
+// Eq returns a Bool mask of where the view is equal to other, broadcasting both
+func (v *ViewStruct) Eq(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x == y })
+}
+
+// Ne returns a Bool mask of where the view is not equal to other, broadcasting both
+func (v *ViewStruct) Ne(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x != y })
+}
+
+// Lt returns a Bool mask of where the view is less than other, broadcasting both
+func (v *ViewStruct) Lt(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x < y })
+}
+
+// Le returns a Bool mask of where the view is less than or equal to other, broadcasting both
+func (v *ViewStruct) Le(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x <= y })
+}
+
+// Gt returns a Bool mask of where the view is greater than other, broadcasting both
+func (v *ViewStruct) Gt(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x > y })
+}
+
+// Ge returns a Bool mask of where the view is greater than or equal to other, broadcasting both
+func (v *ViewStruct) Ge(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x >= y })
+}
+
+// LogicalAnd returns a Bool mask of where both the view and other are non-zero
+func (v *ViewStruct) LogicalAnd(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x != 0 && y != 0 })
+}
+
+// LogicalOr returns a Bool mask of where either the view or other is non-zero
+func (v *ViewStruct) LogicalOr(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return x != 0 || y != 0 })
+}
+
+// LogicalXor returns a Bool mask of where exactly one of the view and other is non-zero
+func (v *ViewStruct) LogicalXor(other Operand) (*TensorStruct, error) {
+	return compare(v, other, func(x, y float64) bool { return (x != 0) != (y != 0) })
+}
+
+// LogicalNot returns a Bool mask of where the view is zero
+func (v *ViewStruct) LogicalNot() *TensorStruct {
+	return unary(v, Bool, func(x float64) float64 { return boolValue(x == 0) })
+}
+
+// MaskedFill returns a copy of the view with the elements where mask is
+// non-zero replaced by value, the mask must broadcast to the view's shape
+func (v *ViewStruct) MaskedFill(mask Operand, value float64) (*TensorStruct, error) {
+	return maskedFill(v, mask, value)
+}
+
+// MaskedSelect returns the elements of the view where mask is non-zero as
+// a 1D tensor, in row-major order
+func (v *ViewStruct) MaskedSelect(mask Operand) (*TensorStruct, error) {
+	return maskedSelect(v, mask)
+}
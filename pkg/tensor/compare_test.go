@@ -0,0 +1,111 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestComparisons tests the comparison and logical operations
+func TestComparisons(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+	threshold := mustNewTensor(t, []int{2}, []float64{2, 3})
+	zeros := mustNewTensor(t, []int{2, 2}, []float64{0, 1, 0, 1})
+
+	testCases := []struct {
+		name         string
+		apply        func() (*TensorStruct, error)
+		expectedData []float64
+		expectErr    bool
+	}{
+		{"Eq", func() (*TensorStruct, error) { return x.Eq(threshold) }, []float64{0, 0, 0, 0}, false},
+		{"EqScalar", func() (*TensorStruct, error) { return x.Eq(NewScalar(3)) }, []float64{0, 0, 1, 0}, false},
+		{"Ne", func() (*TensorStruct, error) { return x.Ne(NewScalar(3)) }, []float64{1, 1, 0, 1}, false},
+		{"Lt", func() (*TensorStruct, error) { return x.Lt(threshold) }, []float64{1, 1, 0, 0}, false},
+		{"Le", func() (*TensorStruct, error) { return x.Le(threshold) }, []float64{1, 1, 0, 0}, false},
+		{"Gt", func() (*TensorStruct, error) { return x.Gt(threshold) }, []float64{0, 0, 1, 1}, false},
+		{"Ge", func() (*TensorStruct, error) { return x.Ge(NewScalar(2)) }, []float64{0, 1, 1, 1}, false},
+		{"LogicalAnd", func() (*TensorStruct, error) { return zeros.LogicalAnd(x) }, []float64{0, 1, 0, 1}, false},
+		{"LogicalOr", func() (*TensorStruct, error) { return zeros.LogicalOr(NewScalar(0)) }, []float64{0, 1, 0, 1}, false},
+		{"LogicalXor", func() (*TensorStruct, error) { return zeros.LogicalXor(NewScalar(1)) }, []float64{1, 0, 1, 0}, false},
+		{"LogicalNot", func() (*TensorStruct, error) { return zeros.LogicalNot(), nil }, []float64{1, 0, 1, 0}, false},
+		{"Incompatible", func() (*TensorStruct, error) { return x.Eq(mustNewTensor(t, []int{3}, []float64{1, 2, 3})) }, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mask, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "DType", Bool, mask.DType())
+			checkEqual(t, "Shape", []int{2, 2}, mask.Shape())
+			checkEqual(t, "Data", tc.expectedData, mask.Data())
+		})
+	}
+}
+
+// TestWhere tests selecting elements with a condition
+func TestWhere(t *testing.T) {
+	cond, _ := NewTensorOf([]int{2, 1}, []bool{true, false})
+	a := mustNewTensor(t, []int{3}, []float64{1, 2, 3})
+	b := NewScalar(-1)
+
+	result, err := Where(cond, a, b)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Shape", []int{2, 3}, result.Shape())
+	checkEqual(t, "Data", []float64{1, 2, 3, -1, -1, -1}, result.Data())
+
+	// The result dtype is promoted from a and b
+	ints, _ := NewTensorOf([]int{3}, []int32{1, 2, 3})
+	promoted, _ := Where(cond, ints, b)
+	checkEqual(t, "DType", Float64, promoted.DType())
+
+	if _, err := Where(cond, mustNewTensor(t, []int{3, 1}, []float64{1, 2, 3}), b); err == nil {
+		t.Error("Expected error for incompatible shapes")
+	}
+}
+
+// TestMasking tests filling and selecting masked elements
+func TestMasking(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, -2, 3, -4, 5, -6})
+
+	// A ReLU as a masked fill
+	negative, _ := x.Lt(NewScalar(0))
+	relu, err := x.MaskedFill(negative, 0)
+	if err != nil {
+		t.Fatalf("MaskedFill: expected no error, got %v", err)
+	}
+	checkEqual(t, "MaskedFill", []float64{1, 0, 3, 0, 5, 0}, relu.Data())
+
+	// A broadcast column mask fills whole rows
+	rows, _ := NewTensorOf([]int{2, 1}, []bool{false, true})
+	filled, err := x.MaskedFill(rows, 9)
+	if err != nil {
+		t.Fatalf("MaskedFill: expected no error, got %v", err)
+	}
+	checkEqual(t, "MaskedFill Rows", []float64{1, -2, 3, 9, 9, 9}, filled.Data())
+
+	// A mask that would expand the tensor is rejected
+	wide, _ := NewTensorOf([]int{2, 2, 3}, make([]bool, 12))
+	if _, err := x.MaskedFill(wide, 0); err == nil {
+		t.Error("MaskedFill: expected error for a mask larger than the tensor")
+	}
+
+	selected, err := x.MaskedSelect(negative)
+	if err != nil {
+		t.Fatalf("MaskedSelect: expected no error, got %v", err)
+	}
+	checkEqual(t, "MaskedSelect", []float64{-2, -4, -6}, selected.Data())
+
+	// Selecting from a transposed view follows the view's order
+	transposedMask, _ := x.Transpose().Gt(NewScalar(0))
+	positive, err := x.Transpose().MaskedSelect(transposedMask)
+	if err != nil {
+		t.Fatalf("MaskedSelect: expected no error, got %v", err)
+	}
+	checkEqual(t, "MaskedSelect View", []float64{1, 5, 3}, positive.Data())
+}
@@ -0,0 +1,184 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"sync/atomic"
+)
+
+// divisionMode is how a DivisionPolicy handles a zero divisor
+type divisionMode int
+
+const (
+	divisionError divisionMode = iota
+	divisionIEEE
+	divisionReplace
+)
+
+// DivisionPolicy decides the result of dividing by zero
+type DivisionPolicy struct {
+	mode  divisionMode
+	value float64
+}
+
+var (
+	// Error fails the division on the first zero divisor
+	Error = DivisionPolicy{mode: divisionError}
+	// IEEE follows IEEE 754, x/0 is ±Inf and 0/0 is NaN
+	IEEE = DivisionPolicy{mode: divisionIEEE}
+)
+
+// ReplaceWith returns the policy storing value for every division by zero
+func ReplaceWith(value float64) DivisionPolicy {
+	return DivisionPolicy{mode: divisionReplace, value: value}
+}
+
+// String returns the name of the policy
+func (p DivisionPolicy) String() string {
+	switch p.mode {
+	case divisionIEEE:
+		return "IEEE"
+	case divisionReplace:
+		return fmt.Sprintf("ReplaceWith(%v)", p.value)
+	default:
+		return "Error"
+	}
+}
+
+// divide returns x / y, handling a zero y as the policy says
+func (p DivisionPolicy) divide(x, y float64) (float64, error) {
+	if y != 0 {
+		return x / y, nil
+	}
+	switch p.mode {
+	case divisionIEEE:
+		return x / y, nil
+	case divisionReplace:
+		return p.value, nil
+	default:
+		return 0, fmt.Errorf("division by zero")
+	}
+}
+
+// divisionPolicy is the policy Div and the other divisions follow
+var divisionPolicy atomic.Pointer[DivisionPolicy]
+
+// SetDivisionPolicy sets the policy Div, DivInPlace and DivInto follow when
+// dividing by zero, the default is Error
+func SetDivisionPolicy(p DivisionPolicy) {
+	divisionPolicy.Store(&p)
+}
+
+// CurrentDivisionPolicy returns the policy set by SetDivisionPolicy
+func CurrentDivisionPolicy() DivisionPolicy {
+	if p := divisionPolicy.Load(); p != nil {
+		return *p
+	}
+	return Error
+}
+
+// divWith divides a by b elementwise, dividing by zero follows the policy
+func divWith(a Operand, b Operand, policy DivisionPolicy) (*TensorStruct, error) {
+	return elementwise(a, b, divisionType(a.DType(), b.DType()), floatOp(policy.divide))
+}
+
+// isNaN marks the NaN elements of x
+func isNaN(x Operand) *TensorStruct {
+	return unary(x, Bool, func(v float64) float64 {
+		return boolValue(math.IsNaN(v))
+	})
+}
+
+// isInf marks the infinite elements of x
+func isInf(x Operand) *TensorStruct {
+	return unary(x, Bool, func(v float64) float64 {
+		return boolValue(math.IsInf(v, 0))
+	})
+}
+
+// nanToNum replaces NaN, +Inf and -Inf in x with the given values
+func nanToNum(x Operand, nan, posInf, negInf float64) *TensorStruct {
+	return unary(x, x.DType(), func(v float64) float64 {
+		switch {
+		case math.IsNaN(v):
+			return nan
+		case math.IsInf(v, 1):
+			return posInf
+		case math.IsInf(v, -1):
+			return negInf
+		default:
+			return v
+		}
+	})
+}
+
+// hasNaN reports whether any element of x is NaN
+func hasNaN(x Operand) bool {
+	if !x.DType().IsFloat() {
+		return false
+	}
+	shape, stride, data := x.Shape(), x.Stride(), x.storage()
+	idx := make([]int, len(shape))
+	for i := 0; i < shapeSize(shape); i++ {
+		if math.IsNaN(data.get(offsetAt(x, stride, idx))) {
+			return true
+		}
+		nextIndex(idx, shape)
+	}
+	return false
+}
+
+// DivWith divides this tensor by other, dividing by zero follows the policy
+func (t *TensorStruct) DivWith(other Operand, policy DivisionPolicy) (*TensorStruct, error) {
+	return divWith(t, other, policy)
+}
+
+// IsNaN returns a Bool tensor marking the NaN elements of the tensor
+func (t *TensorStruct) IsNaN() *TensorStruct {
+	return isNaN(t)
+}
+
+// IsInf returns a Bool tensor marking the infinite elements of the tensor
+func (t *TensorStruct) IsInf() *TensorStruct {
+	return isInf(t)
+}
+
+// NanToNum returns the tensor with NaN, +Inf and -Inf replaced by the given values
+func (t *TensorStruct) NanToNum(nan, posInf, negInf float64) *TensorStruct {
+	return nanToNum(t, nan, posInf, negInf)
+}
+
+// HasNaN reports whether any element of the tensor is NaN
+func (t *TensorStruct) HasNaN() bool {
+	return hasNaN(t)
+}
+
+// DivWith divides this view by other, dividing by zero follows the policy
+func (v *ViewStruct) DivWith(other Operand, policy DivisionPolicy) (*TensorStruct, error) {
+	return divWith(v, other, policy)
+}
+
+// IsNaN returns a Bool tensor marking the NaN elements of the view
+func (v *ViewStruct) IsNaN() *TensorStruct {
+	return isNaN(v)
+}
+
+// IsInf returns a Bool tensor marking the infinite elements of the view
+func (v *ViewStruct) IsInf() *TensorStruct {
+	return isInf(v)
+}
+
+// NanToNum returns the view with NaN, +Inf and -Inf replaced by the given values
+func (v *ViewStruct) NanToNum(nan, posInf, negInf float64) *TensorStruct {
+	return nanToNum(v, nan, posInf, negInf)
+}
+
+// HasNaN reports whether any element of the view is NaN
+func (v *ViewStruct) HasNaN() bool {
+	return hasNaN(v)
+}
+
+// DivWith divides this broadcast by other, dividing by zero follows the policy
+func (b *BroadcastStruct) DivWith(other Operand, policy DivisionPolicy) (*TensorStruct, error) {
+	return divWith(b, other, policy)
+}
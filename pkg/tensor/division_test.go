@@ -0,0 +1,90 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+// TestDivisionPolicy tests dividing by zero under each policy
+func TestDivisionPolicy(t *testing.T) {
+	x := mustNewTensor(t, []int{3}, []float64{1, -1, 0})
+	zero := NewScalar(0)
+
+	testCases := []struct {
+		name      string
+		policy    DivisionPolicy
+		check     func(v float64) bool
+		expectErr bool
+	}{
+		{"Error", Error, nil, true},
+		{"IEEE", IEEE, nil, false},
+		{"ReplaceWith", ReplaceWith(-7), func(v float64) bool { return v == -7 }, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := x.DivWith(zero, tc.policy)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			data := result.Data()
+			if tc.check != nil {
+				for _, v := range data {
+					if !tc.check(v) {
+						t.Errorf("Unexpected value %v", v)
+					}
+				}
+				return
+			}
+			if !math.IsInf(data[0], 1) || !math.IsInf(data[1], -1) || !math.IsNaN(data[2]) {
+				t.Errorf("Expected [+Inf -Inf NaN], got %v", data)
+			}
+		})
+	}
+
+	checkEqual(t, "String", "ReplaceWith(-7)", ReplaceWith(-7).String())
+}
+
+// TestSetDivisionPolicy tests the package-level policy followed by Div
+func TestSetDivisionPolicy(t *testing.T) {
+	defer SetDivisionPolicy(Error)
+	x := mustNewTensor(t, []int{2}, []float64{4, 1})
+	divisor := mustNewTensor(t, []int{2}, []float64{2, 0})
+
+	checkEqual(t, "Default", Error, CurrentDivisionPolicy())
+	if _, err := x.Div(divisor); err == nil {
+		t.Error("Expected division by zero error by default")
+	}
+
+	SetDivisionPolicy(ReplaceWith(0))
+	result, err := x.Div(divisor)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Div", []float64{2, 0}, result.Data())
+
+	if err := x.DivInPlace(divisor); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "DivInPlace", []float64{2, 0}, x.Data())
+}
+
+// TestNaNHelpers tests detecting and replacing NaN and infinite values
+func TestNaNHelpers(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 2}, []float64{1, math.NaN(), math.Inf(1), math.Inf(-1)})
+
+	checkEqual(t, "IsNaN", []float64{0, 1, 0, 0}, x.IsNaN().Data())
+	checkEqual(t, "IsInf", []float64{0, 0, 1, 1}, x.IsInf().Data())
+	checkEqual(t, "IsNaN DType", Bool, x.IsNaN().DType())
+	checkEqual(t, "NanToNum", []float64{1, 0, 1e9, -1e9}, x.NanToNum(0, 1e9, -1e9).Data())
+	checkEqual(t, "HasNaN", true, x.HasNaN())
+
+	// The first column holds no NaN
+	column, _ := x.Slice(All(), Index(0))
+	checkEqual(t, "HasNaN View", false, column.HasNaN())
+	ints, _ := NewTensorOf([]int{2}, []int32{1, 2})
+	checkEqual(t, "HasNaN Integer", false, ints.HasNaN())
+}
@@ -0,0 +1,117 @@
+package tensor
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// DType identifies the type of the elements stored in a tensor
+type DType int
+
+const (
+	// Float64 stores 64-bit floating point elements
+	Float64 DType = iota
+	// Float32 stores 32-bit floating point elements
+	Float32
+	// Int64 stores 64-bit signed integer elements
+	Int64
+	// Int32 stores 32-bit signed integer elements
+	Int32
+	// Uint8 stores 8-bit unsigned integer elements
+	Uint8
+	// Bool stores boolean elements
+	Bool
+)
+
+// Element is the set of Go types a tensor can store
+type Element interface {
+	float64 | float32 | int64 | int32 | uint8 | bool
+}
+
+// String returns the name of the dtype
+func (d DType) String() string {
+	switch d {
+	case Float64:
+		return "float64"
+	case Float32:
+		return "float32"
+	case Int64:
+		return "int64"
+	case Int32:
+		return "int32"
+	case Uint8:
+		return "uint8"
+	case Bool:
+		return "bool"
+	default:
+		return fmt.Sprintf("DType(%d)", int(d))
+	}
+}
+
+// IsFloat reports whether the dtype stores floating point elements
+func (d DType) IsFloat() bool {
+	return d == Float64 || d == Float32
+}
+
+// valid reports whether the dtype is one of the supported dtypes
+func (d DType) valid() bool {
+	return d >= Float64 && d <= Bool
+}
+
+// promotionRank orders the dtypes from narrowest to widest
+func promotionRank(d DType) int {
+	switch d {
+	case Bool:
+		return 0
+	case Uint8:
+		return 1
+	case Int32:
+		return 2
+	case Int64:
+		return 3
+	case Float32:
+		return 4
+	default:
+		return 5
+	}
+}
+
+// promoteTypes returns the narrowest dtype that can hold elements of both dtypes
+func promoteTypes(a DType, b DType) DType {
+	if promotionRank(b) > promotionRank(a) {
+		return b
+	}
+	return a
+}
+
+// arithmeticType returns the dtype produced by Add, Sub and Mul on the given dtypes,
+// booleans are counted as integers so they are promoted to Int64
+func arithmeticType(a DType, b DType) DType {
+	dtype := promoteTypes(a, b)
+	if dtype == Bool {
+		return Int64
+	}
+	return dtype
+}
+
+// divisionType returns the dtype produced by Div on the given dtypes,
+// division always produces floating point elements
+func divisionType(a DType, b DType) DType {
+	dtype := promoteTypes(a, b)
+	if !dtype.IsFloat() {
+		return Float64
+	}
+	return dtype
+}
+
+// formatValue formats a single element of the given dtype as a string
+func formatValue(v float64, dtype DType) string {
+	switch {
+	case dtype == Bool:
+		return strconv.FormatBool(v != 0)
+	case dtype.IsFloat():
+		return fmt.Sprintf("%.2f", v)
+	default:
+		return strconv.FormatInt(int64(v), 10)
+	}
+}
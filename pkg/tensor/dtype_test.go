@@ -0,0 +1,205 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestNewTensorOf tests creating tensors of every dtype
+func TestNewTensorOf(t *testing.T) {
+	testCases := []struct {
+		name          string
+		create        func() (*TensorStruct, error)
+		expectedDType DType
+		expectedData  []float64
+	}{
+		{"Float64", func() (*TensorStruct, error) { return NewTensorOf([]int{2}, []float64{1.5, 2.5}) }, Float64, []float64{1.5, 2.5}},
+		{"Float32", func() (*TensorStruct, error) { return NewTensorOf([]int{2}, []float32{1.5, 2.5}) }, Float32, []float64{1.5, 2.5}},
+		{"Int64", func() (*TensorStruct, error) { return NewTensorOf([]int{2}, []int64{-1, 2}) }, Int64, []float64{-1, 2}},
+		{"Int32", func() (*TensorStruct, error) { return NewTensorOf([]int{2}, []int32{-3, 4}) }, Int32, []float64{-3, 4}},
+		{"Uint8", func() (*TensorStruct, error) { return NewTensorOf([]int{2}, []uint8{0, 255}) }, Uint8, []float64{0, 255}},
+		{"Bool", func() (*TensorStruct, error) { return NewTensorOf([]int{2}, []bool{true, false}) }, Bool, []float64{1, 0}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tensor, err := tc.create()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "DType", tc.expectedDType, tensor.DType())
+			checkEqual(t, "Shape", []int{2}, tensor.Shape())
+			checkEqual(t, "Data", tc.expectedData, tensor.Data())
+		})
+	}
+}
+
+// TestDataOf tests reading the typed data of a tensor
+func TestDataOf(t *testing.T) {
+	labels := []int64{3, 1, 4}
+	tensor, err := NewTensorOf([]int{3}, labels)
+	if err != nil {
+		t.Fatalf("Failed to create tensor: %v", err)
+	}
+
+	data, err := DataOf[int64](tensor)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Data", labels, data)
+
+	if _, err := DataOf[float64](tensor); err == nil {
+		t.Error("Expected dtype mismatch error, got nil")
+	}
+}
+
+// TestAsType tests converting tensors between dtypes
+func TestAsType(t *testing.T) {
+	testCases := []struct {
+		name         string
+		data         []float64
+		dtype        DType
+		expectedData []float64
+		expectErr    bool
+	}{
+		{"ToFloat32", []float64{1.25, -2.5}, Float32, []float64{1.25, -2.5}, false},
+		{"ToInt64Truncates", []float64{1.75, -2.75}, Int64, []float64{1, -2}, false},
+		{"ToInt32Truncates", []float64{3.9, -0.5}, Int32, []float64{3, 0}, false},
+		{"ToUint8Wraps", []float64{255, 256}, Uint8, []float64{255, 0}, false},
+		{"ToBool", []float64{0, -3}, Bool, []float64{0, 1}, false},
+		{"InvalidDType", []float64{1, 2}, DType(99), nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tensor := mustNewTensor(t, []int{2}, tc.data)
+			converted, err := tensor.AsType(tc.dtype)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "DType", tc.dtype, converted.DType())
+			checkEqual(t, "Data", tc.expectedData, converted.Data())
+		})
+	}
+}
+
+// TestDTypePromotion tests the dtype produced by the arithmetic ops
+func TestDTypePromotion(t *testing.T) {
+	mustOf := func(dtype DType) *TensorStruct {
+		tensor, err := mustNewTensor(t, []int{2}, []float64{1, 2}).AsType(dtype)
+		if err != nil {
+			t.Fatalf("Failed to convert tensor: %v", err)
+		}
+		return tensor
+	}
+
+	testCases := []struct {
+		name        string
+		a, b        DType
+		expectedAdd DType
+		expectedDiv DType
+	}{
+		{"Float64Float32", Float64, Float32, Float64, Float64},
+		{"Float32Int64", Float32, Int64, Float32, Float32},
+		{"Int32Int64", Int32, Int64, Int64, Float64},
+		{"Uint8Int32", Uint8, Int32, Int32, Float64},
+		{"BoolUint8", Bool, Uint8, Uint8, Float64},
+		{"BoolBool", Bool, Bool, Int64, Float64},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			add, err := mustOf(tc.a).Add(mustOf(tc.b))
+			if err != nil {
+				t.Fatalf("Add: expected no error, got %v", err)
+			}
+			checkEqual(t, "Add DType", tc.expectedAdd, add.DType())
+
+			div, err := mustOf(tc.a).Div(mustOf(tc.b))
+			if err != nil {
+				t.Fatalf("Div: expected no error, got %v", err)
+			}
+			checkEqual(t, "Div DType", tc.expectedDiv, div.DType())
+		})
+	}
+
+	// Integer division produces floating point results
+	a, _ := NewTensorOf([]int{2}, []int64{1, 3})
+	b, _ := NewTensorOf([]int{2}, []int64{2, 2})
+	div, err := a.Div(b)
+	if err != nil {
+		t.Fatalf("Div: expected no error, got %v", err)
+	}
+	checkEqual(t, "Div Data", []float64{0.5, 1.5}, div.Data())
+}
+
+// TestDTypeString tests the string representation of each dtype
+func TestDTypeString(t *testing.T) {
+	floats, _ := NewTensorOf([]int{2}, []float32{1, 2.5})
+	ints, _ := NewTensorOf([]int{2, 2}, []int32{1, -2, 3, 4})
+	bools, _ := NewTensorOf([]int{2}, []bool{true, false})
+
+	checkEqual(t, "Float32", "[1.00 2.50]", floats.String())
+	checkEqual(t, "Int32", "[\n 1 -2\n 3 4\n]", ints.String())
+	checkEqual(t, "Bool", "[true false]", bools.String())
+	checkEqual(t, "DType", "uint8", Uint8.String())
+}
+
+// TestInt64Exact tests that integer arithmetic, reductions, scans and copies
+// keep int64 values beyond 2^53 exact
+func TestInt64Exact(t *testing.T) {
+	big := int64(1)<<53 + 1
+	x, _ := NewTensorOf([]int{2, 2}, []int64{big, 0, big, 2})
+	zero, _ := NewTensorOf([]int{1}, []int64{0})
+	one, _ := NewTensorOf([]int{1}, []bool{true})
+	pair, _ := NewTensorOf([]int{2}, []int64{big, big})
+
+	testCases := []struct {
+		name     string
+		apply    func() (*TensorStruct, error)
+		expected []int64
+	}{
+		{"Add", func() (*TensorStruct, error) { return x.Add(zero) }, []int64{big, 0, big, 2}},
+		{"AddBool", func() (*TensorStruct, error) { return x.Add(one) }, []int64{big + 1, 1, big + 1, 3}},
+		{"Sub", func() (*TensorStruct, error) { return x.Sub(x.Transpose()) }, []int64{0, -big, big, 0}},
+		{"Mul", func() (*TensorStruct, error) { return x.Mul(x) }, []int64{big * big, 0, big * big, 4}},
+		{"Sum", func() (*TensorStruct, error) { return x.Sum([]int{1}, false) }, []int64{big, big + 2}},
+		{"SumAll", func() (*TensorStruct, error) { return x.Sum(nil, false) }, []int64{2*big + 2}},
+		{"Prod", func() (*TensorStruct, error) { return x.Prod([]int{0}, false) }, []int64{big * big, 0}},
+		{"Max", func() (*TensorStruct, error) { return x.Max([]int{0}, false) }, []int64{big, 2}},
+		{"CumSum", func() (*TensorStruct, error) { return x.CumSum(0) }, []int64{big, 0, 2 * big, 2}},
+		{"CumProd", func() (*TensorStruct, error) { return x.Transpose().CumProd(1) }, []int64{big, big * big, 0, 0}},
+		{"CumMin", func() (*TensorStruct, error) { return x.CumMin(1) }, []int64{big, 0, big, 2}},
+		{"Diff", func() (*TensorStruct, error) { return x.Diff(1, 0) }, []int64{0, 2}},
+		{"Contiguous", func() (*TensorStruct, error) { return x.Transpose().Contiguous(), nil }, []int64{big, big, 0, 2}},
+		{"Concat", func() (*TensorStruct, error) { return Concat(0, x, x) }, []int64{big, 0, big, 2, big, 0, big, 2}},
+		{"Take", func() (*TensorStruct, error) { return x.Take(mustIndex(t, []int{1}, []int64{2})) }, []int64{big}},
+		{"DiagExtract", func() (*TensorStruct, error) { return Diag(x) }, []int64{big, 2}},
+		{"DiagPlace", func() (*TensorStruct, error) { return Diag(pair) }, []int64{big, 0, 0, big}},
+		{"Shuffle", func() (*TensorStruct, error) { return NewRNG(1).Shuffle(pair) }, []int64{big, big}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			data, err := DataOf[int64](result)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "Data", tc.expected, data)
+		})
+	}
+
+	// In-place arithmetic is exact too
+	y, _ := NewTensorOf([]int{1}, []int64{big})
+	if err := y.AddInPlace(one); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	data, _ := DataOf[int64](y)
+	checkEqual(t, "AddInPlace", []int64{big + 1}, data)
+}
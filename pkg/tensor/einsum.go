@@ -0,0 +1,360 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// einsumTerm is an operand of an einsum with a subscript label for each of
+// its axes, the letters of the spec label axes by their rune and the axes of
+// an ellipsis are labelled -1, -2, ... counting from the right
+type einsumTerm struct {
+	labels []int
+	shape  []int
+	stride []int
+	offset int
+	data   storage
+}
+
+// labelName returns the subscript a label was parsed from
+func labelName(label int) string {
+	if label < 0 {
+		return "..."
+	}
+	return string(rune(label))
+}
+
+// parseSubscripts parses the subscripts of one operand, the axes covered by
+// an ellipsis are returned as -1 labels to be expanded once ranks are known
+func parseSubscripts(subscripts string) ([]int, error) {
+	labels := []int{}
+	ellipsis := false
+	for i := 0; i < len(subscripts); i++ {
+		c := subscripts[i]
+		switch {
+		case strings.HasPrefix(subscripts[i:], "..."):
+			if ellipsis {
+				return nil, fmt.Errorf("subscripts %q contain more than one ellipsis", subscripts)
+			}
+			ellipsis = true
+			labels = append(labels, -1)
+			i += 2
+		case (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			labels = append(labels, int(c))
+		default:
+			return nil, fmt.Errorf("invalid subscript %q in %q", c, subscripts)
+		}
+	}
+	return labels, nil
+}
+
+// expandEllipsisLabels replaces the ellipsis marker in labels with n labels
+// counting down to -1
+func expandEllipsisLabels(labels []int, n int) []int {
+	expanded := []int{}
+	for _, label := range labels {
+		if label != -1 {
+			expanded = append(expanded, label)
+			continue
+		}
+		for j := n; j > 0; j-- {
+			expanded = append(expanded, -j)
+		}
+	}
+	return expanded
+}
+
+// parseEinsum parses an einsum spec for operands of the given ranks, returning
+// the labels of every input and of the output
+func parseEinsum(spec string, ranks []int) ([][]int, []int, error) {
+	spec = strings.Join(strings.Fields(spec), "")
+	inputSpec, outputSpec, explicit := strings.Cut(spec, "->")
+
+	// Parse the inputs, expanding each ellipsis to the axes it covers
+	subscripts := strings.Split(inputSpec, ",")
+	if len(subscripts) != len(ranks) {
+		return nil, nil, fmt.Errorf("einsum spec %q names %d operands, got %d", spec, len(subscripts), len(ranks))
+	}
+	inputs := make([][]int, len(ranks))
+	ellipsisRank := 0
+	for i, s := range subscripts {
+		labels, err := parseSubscripts(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		named := len(labels)
+		if slices.Contains(labels, -1) {
+			named--
+		}
+		covered := ranks[i] - named
+		if covered < 0 || (covered > 0 && !slices.Contains(labels, -1)) {
+			return nil, nil, fmt.Errorf("operand %d has rank %d but subscripts %q name %d axes", i, ranks[i], s, named)
+		}
+		inputs[i] = expandEllipsisLabels(labels, covered)
+		ellipsisRank = max(ellipsisRank, covered)
+	}
+
+	// Parse an explicit output, which may only name input subscripts once
+	if explicit {
+		labels, err := parseSubscripts(outputSpec)
+		if err != nil {
+			return nil, nil, err
+		}
+		output := expandEllipsisLabels(labels, ellipsisRank)
+		for i, label := range output {
+			if slices.Contains(output[:i], label) {
+				return nil, nil, fmt.Errorf("output subscript %s repeated in %q", labelName(label), outputSpec)
+			}
+			if label > 0 && !slices.ContainsFunc(inputs, func(in []int) bool { return slices.Contains(in, label) }) {
+				return nil, nil, fmt.Errorf("output subscript %s does not appear in the inputs", labelName(label))
+			}
+		}
+		return inputs, output, nil
+	}
+
+	// An implicit output keeps the ellipsis axes followed by the subscripts
+	// used exactly once, in alphabetical order
+	counts := map[int]int{}
+	for _, labels := range inputs {
+		for _, label := range labels {
+			counts[label]++
+		}
+	}
+	output := expandEllipsisLabels([]int{-1}, ellipsisRank)
+	letters := []int{}
+	for label, count := range counts {
+		if label > 0 && count == 1 {
+			letters = append(letters, label)
+		}
+	}
+	slices.Sort(letters)
+	return inputs, append(output, letters...), nil
+}
+
+// newEinsumTerm builds the term for x labelled with labels, an axis labelled
+// more than once is read along its diagonal by summing the strides of the
+// repeated axes, and ellipsis axes of size 1 broadcast to the size of the label
+func newEinsumTerm(x Operand, labels []int, sizes map[int]int) *einsumTerm {
+	term := &einsumTerm{
+		labels: []int{},
+		shape:  []int{},
+		stride: []int{},
+		offset: x.Offset(),
+		data:   x.storage(),
+	}
+	for i, label := range labels {
+		stride := x.Stride()[i]
+		if x.Shape()[i] != sizes[label] {
+			stride = 0
+		}
+		if j := slices.Index(term.labels, label); j >= 0 {
+			term.stride[j] += stride
+			continue
+		}
+		term.labels = append(term.labels, label)
+		term.shape = append(term.shape, sizes[label])
+		term.stride = append(term.stride, stride)
+	}
+	return term
+}
+
+// pack returns the elements of the term with its axes reordered to labels,
+// packed in row-major order
+func (e *einsumTerm) pack(labels []int) []float64 {
+	shape := make([]int, len(labels))
+	stride := make([]int, len(labels))
+	for i, label := range labels {
+		j := slices.Index(e.labels, label)
+		shape[i], stride[i] = e.shape[j], e.stride[j]
+	}
+	return toFloat64s(gather(e.data, shape, stride, e.offset))
+}
+
+// sumOut sums the term over every axis whose label keep rejects
+func (e *einsumTerm) sumOut(keep func(label int) bool) *einsumTerm {
+	// Split the labels into kept and summed, returning the term when nothing is summed
+	kept, summed := []int{}, []int{}
+	shape := []int{}
+	for i, label := range e.labels {
+		if keep(label) {
+			kept = append(kept, label)
+			shape = append(shape, e.shape[i])
+		} else {
+			summed = append(summed, label)
+		}
+	}
+	if len(summed) == 0 {
+		return e
+	}
+
+	// Pack the summed axes innermost and add up each run
+	packed := e.pack(append(append([]int{}, kept...), summed...))
+	size := shapeSize(shape)
+	result := allocFloat64s(size)
+	if size > 0 {
+		run := len(packed) / size
+		for i := range result {
+			for _, v := range packed[i*run : (i+1)*run] {
+				result[i] += v
+			}
+		}
+	}
+	return &einsumTerm{labels: kept, shape: shape, stride: computeStrides(shape), data: float64Storage(result)}
+}
+
+// contract multiplies the terms a and b, summing every shared axis whose label
+// keep rejects, axes held by only one term are summed out first
+func contract(a *einsumTerm, b *einsumTerm, keep func(label int) bool) *einsumTerm {
+	inB := func(label int) bool { return slices.Contains(b.labels, label) }
+	inA := func(label int) bool { return slices.Contains(a.labels, label) }
+	a = a.sumOut(func(label int) bool { return keep(label) || inB(label) })
+	b = b.sumOut(func(label int) bool { return keep(label) || inA(label) })
+
+	// Group the labels into batch, left only, right only and contracted axes
+	var batch, left, right, summed []int
+	sizes := map[int]int{}
+	for i, label := range a.labels {
+		sizes[label] = a.shape[i]
+		switch {
+		case inB(label) && keep(label):
+			batch = append(batch, label)
+		case inB(label):
+			summed = append(summed, label)
+		default:
+			left = append(left, label)
+		}
+	}
+	for i, label := range b.labels {
+		sizes[label] = b.shape[i]
+		if !inA(label) {
+			right = append(right, label)
+		}
+	}
+	sizeOf := func(labels []int) int {
+		size := 1
+		for _, label := range labels {
+			size *= sizes[label]
+		}
+		return size
+	}
+
+	// Multiply the matrices of every batch
+	batches, m, k, n := sizeOf(batch), sizeOf(left), sizeOf(summed), sizeOf(right)
+	aData := a.pack(slices.Concat(batch, left, summed))
+	bData := b.pack(slices.Concat(batch, summed, right))
+	result := allocFloat64s(batches * m * n)
+	for i := 0; i < batches; i++ {
+		gemm(aData[i*m*k:(i+1)*m*k], bData[i*k*n:(i+1)*k*n], result[i*m*n:(i+1)*m*n], m, k, n)
+	}
+
+	// Return the product labelled batch, left then right
+	labels := slices.Concat(batch, left, right)
+	shape := make([]int, len(labels))
+	for i, label := range labels {
+		shape[i] = sizes[label]
+	}
+	return &einsumTerm{labels: labels, shape: shape, stride: computeStrides(shape), data: float64Storage(result)}
+}
+
+// contractedSize returns the number of elements of the contraction of a and
+// b keeping the labels for which keep is true, a label shared by a and b is
+// counted once
+func contractedSize(a *einsumTerm, b *einsumTerm, sizes map[int]int, keep func(label int) bool) int {
+	labels := slices.Concat(a.labels, b.labels)
+	slices.Sort(labels)
+	size := 1
+	for _, label := range slices.Compact(labels) {
+		if keep(label) {
+			size *= sizes[label]
+		}
+	}
+	return size
+}
+
+// Einsum evaluates the Einstein summation spec over the operands, for example
+// "ij,jk->ik" is a matrix product, "ii->" a trace and "bhqd,bhkd->bhqk" a
+// batched product, without "->" the output keeps the subscripts used once in
+// alphabetical order, an ellipsis stands for axes that broadcast between
+// operands, and multiple operands are contracted pairwise, cheapest first
+func Einsum(spec string, operands ...Operand) (*TensorStruct, error) {
+	if len(operands) == 0 {
+		return nil, fmt.Errorf("einsum requires at least one operand")
+	}
+
+	// Parse the spec against the ranks of the operands
+	ranks := make([]int, len(operands))
+	dtype := operands[0].DType()
+	for i, x := range operands {
+		ranks[i] = len(x.Shape())
+		dtype = promoteTypes(dtype, x.DType())
+	}
+	inputs, output, err := parseEinsum(spec, ranks)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check every subscript has one size, ellipsis axes of size 1 broadcast
+	sizes := map[int]int{}
+	for i, labels := range inputs {
+		for j, label := range labels {
+			dim := operands[i].Shape()[j]
+			size, seen := sizes[label]
+			switch {
+			case !seen || (label < 0 && size == 1):
+				sizes[label] = dim
+			case dim != size && !(label < 0 && dim == 1):
+				return nil, fmt.Errorf("operand %d has size %d for subscript %s at axis %d, expected %d", i, dim, labelName(label), j, size)
+			}
+		}
+	}
+
+	// Build the terms, summing out axes no other operand or the output needs
+	terms := make([]*einsumTerm, len(operands))
+	for i, x := range operands {
+		terms[i] = newEinsumTerm(x, inputs[i], sizes)
+	}
+	needed := func(label int, skip ...int) bool {
+		if slices.Contains(output, label) {
+			return true
+		}
+		for i, term := range terms {
+			if !slices.Contains(skip, i) && slices.Contains(term.labels, label) {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range terms {
+		terms[i] = terms[i].sumOut(func(label int) bool { return needed(label, i) })
+	}
+
+	// Contract the pair with the smallest result until one term is left
+	for len(terms) > 1 {
+		bestI, bestJ, bestSize := 0, 1, -1
+		for i := range terms {
+			for j := i + 1; j < len(terms); j++ {
+				size := contractedSize(terms[i], terms[j], sizes, func(label int) bool { return needed(label, i, j) })
+				if bestSize < 0 || size < bestSize {
+					bestI, bestJ, bestSize = i, j, size
+				}
+			}
+		}
+		product := contract(terms[bestI], terms[bestJ], func(label int) bool { return needed(label, bestI, bestJ) })
+		terms[bestI] = product
+		terms = slices.Delete(terms, bestJ, bestJ+1)
+	}
+
+	// Sum out the remaining axes and order the result as the output
+	final := terms[0].sumOut(func(label int) bool { return slices.Contains(output, label) })
+	values := final.pack(output)
+	shape := make([]int, len(output))
+	for i, label := range output {
+		shape[i] = sizes[label]
+	}
+	result := newStorage(arithmeticType(dtype, dtype), len(values))
+	for i, v := range values {
+		result.set(i, v)
+	}
+	return newTensor(shape, result), nil
+}
@@ -0,0 +1,93 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestEinsum tests Einstein summation specs against their known results
+func TestEinsum(t *testing.T) {
+	a := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	b := mustNewTensor(t, []int{3, 2}, []float64{7, 8, 9, 10, 11, 12})
+	square := mustNewTensor(t, []int{3, 3}, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	v := mustNewTensor(t, []int{3}, []float64{1, 0, -1})
+	batch := mustNewTensor(t, []int{2, 1, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	testCases := []struct {
+		name          string
+		spec          string
+		operands      []Operand
+		expectedShape []int
+		expectedData  []float64
+		expectErr     bool
+	}{
+		{"MatMul", "ij,jk->ik", []Operand{a, b}, []int{2, 2}, []float64{58, 64, 139, 154}, false},
+		{"ImplicitMatMul", "ij,jk", []Operand{a, b}, []int{2, 2}, []float64{58, 64, 139, 154}, false},
+		{"ImplicitOrder", "ba", []Operand{a}, []int{3, 2}, []float64{1, 4, 2, 5, 3, 6}, false},
+		{"Transpose", "ij->ji", []Operand{a}, []int{3, 2}, []float64{1, 4, 2, 5, 3, 6}, false},
+		{"Sum", "ij->", []Operand{a}, []int{}, []float64{21}, false},
+		{"ColumnSum", "ij->j", []Operand{a}, []int{3}, []float64{5, 7, 9}, false},
+		{"Trace", "ii", []Operand{square}, []int{}, []float64{15}, false},
+		{"Diagonal", "ii->i", []Operand{square}, []int{3}, []float64{1, 5, 9}, false},
+		{"Dot", "i,i", []Operand{v, v}, []int{}, []float64{2}, false},
+		{"Outer", "i,j->ij", []Operand{v, mustNewTensor(t, []int{2}, []float64{1, 2})}, []int{3, 2}, []float64{1, 2, 0, 0, -1, -2}, false},
+		{"Bilinear", "i,ij,j->", []Operand{v, square, v}, []int{}, []float64{0}, false},
+		{"Chain", "ij,jk,kl->il", []Operand{a, b, mustNewTensor(t, []int{2, 1}, []float64{1, -1})}, []int{2, 1}, []float64{-6, -15}, false},
+		{"Batched", "bqd,kd->bqk", []Operand{batch, b.Transpose()}, []int{2, 1, 2}, []float64{58, 64, 139, 154}, false},
+		{"Ellipsis", "...d,d->...", []Operand{batch, v}, []int{2, 1}, []float64{-2, -2}, false},
+		{"EllipsisBroadcast", "...ij,...jk->...ik", []Operand{batch, b}, []int{2, 1, 2}, []float64{58, 64, 139, 154}, false},
+		{"OperandCount", "ij,jk->ik", []Operand{a}, nil, nil, true},
+		{"RankMismatch", "ijk->i", []Operand{a}, nil, nil, true},
+		{"SizeMismatch", "ij,ij->ij", []Operand{a, b}, nil, nil, true},
+		{"UnknownOutput", "ij->k", []Operand{a}, nil, nil, true},
+		{"RepeatedOutput", "ij->ii", []Operand{a}, nil, nil, true},
+		{"InvalidSubscript", "i1->i", []Operand{a}, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := Einsum(tc.spec, tc.operands...)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			checkEqual(t, "Data", tc.expectedData, result.Data())
+		})
+	}
+}
+
+// TestEinsumAttention tests the batched attention scores against MatMul
+func TestEinsumAttention(t *testing.T) {
+	rng := NewRNG(3)
+	q, _ := rng.Randn([]int{2, 3, 4, 5})
+	k, _ := rng.Randn([]int{2, 3, 6, 5})
+
+	scores, err := Einsum("bhqd,bhkd->bhqk", q, k)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	kt, _ := k.SwapAxes(-1, -2)
+	expected, _ := q.MatMul(kt)
+	checkEqual(t, "Shape", []int{2, 3, 4, 6}, scores.Shape())
+	if !almostEqual(expected.Data(), scores.Data()) {
+		t.Error("Einsum attention scores do not match MatMul")
+	}
+
+	ints, _ := NewTensorOf([]int{2}, []int32{1, 2})
+	dot, _ := Einsum("i,i", ints, ints)
+	checkEqual(t, "DType", Int32, dot.DType())
+}
+
+// TestContractedSize tests that labels shared by both terms count once in
+// the size of their contraction
+func TestContractedSize(t *testing.T) {
+	i, j, k := int('i'), int('j'), int('k')
+	sizes := map[int]int{i: 2, j: 3, k: 4}
+	a := &einsumTerm{labels: []int{i, j}}
+	b := &einsumTerm{labels: []int{j, k}}
+
+	checkEqual(t, "Kept", 24, contractedSize(a, b, sizes, func(int) bool { return true }))
+	checkEqual(t, "Summed", 8, contractedSize(a, b, sizes, func(label int) bool { return label != j }))
+}
@@ -0,0 +1,198 @@
+package tensor
+
+import (
+	"slices"
+	"sync"
+)
+
+// Operand is the read interface shared by tensors, views and broadcasts, the
+// element at an index is read from the storage at the offset plus the sum of
+// the index times the strides
+type Operand interface {
+	Shape() []int
+	Stride() []int
+	Offset() int
+	DType() DType
+
+	storage() storage
+}
+
+// isPacked reports whether data laid out with the given shape and strides is
+// packed in row-major order, the strides of size 1 axes are ignored
+func isPacked(shape []int, stride []int) bool {
+	expected := 1
+	for i := len(shape) - 1; i >= 0; i-- {
+		if shape[i] != 1 && stride[i] != expected {
+			return false
+		}
+		expected *= shape[i]
+	}
+	return true
+}
+
+// contiguousStorage returns the elements of x packed in row-major order,
+// sharing x's storage when its elements are already packed
+func contiguousStorage(x Operand) storage {
+	shape := x.Shape()
+	size := shapeSize(shape)
+	if size > 0 && isPacked(shape, x.Stride()) {
+		return x.storage().slice(x.Offset(), x.Offset()+size)
+	}
+	return gather(x.storage(), shape, x.Stride(), x.Offset())
+}
+
+// binaryOp is an elementwise operation, float applies it to float64 elements
+// and exact, when set, to int64 elements, exact is used when the operands and
+// the result all have integer or boolean dtypes so integers beyond 2^53 stay exact
+type binaryOp struct {
+	float func(x, y float64) (float64, error)
+	exact func(x, y int64) int64
+}
+
+// floatOp returns the binaryOp applying op to float64 elements only
+func floatOp(op func(x, y float64) (float64, error)) binaryOp {
+	return binaryOp{float: op}
+}
+
+// elementwise applies op to every pair of elements of a and b, broadcasting
+// both operands to their common shape while keeping a on the left of op, the
+// result is stored with the given dtype
+func elementwise(a Operand, b Operand, dtype DType, op binaryOp) (*TensorStruct, error) {
+	// Compute the shape of the result
+	shape, err := broadcastShapes(a.Shape(), b.Shape())
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize the result and fill it
+	result := newTensor(shape, newStorage(dtype, shapeSize(shape)))
+	if err := elementwiseTo(result, a, b, op); err != nil {
+		return nil, err
+	}
+
+	// Return the new tensor, with the result data
+	return result, nil
+}
+
+// elementwiseTo applies op to every pair of elements of a and b like
+// elementwise, writing the results through the strides of dst, which must
+// have the broadcast shape of a and b
+func elementwiseTo(dst Operand, a Operand, b Operand, op binaryOp) error {
+	// Compute the strides used to read each operand at the result shape
+	shape, stride := dst.Shape(), dst.Stride()
+	aStride := expandStrides(a.Shape(), a.Stride(), shape)
+	bStride := expandStrides(b.Shape(), b.Stride(), shape)
+
+	// Copy an operand that shares memory with dst, unless it reads every
+	// element from the position the result is written to
+	a, aStride = detach(dst, a, aStride)
+	b, bStride = detach(dst, b, bStride)
+
+	// Walk the result in row-major order across the workers, each chunk
+	// tracking each operand's offset from the start of the chunk
+	size := shapeSize(shape)
+	data, aData, bData := dst.storage(), a.storage(), b.storage()
+	exact := op.exact != nil && isExact(dst.DType(), a.DType(), b.DType())
+	var mu sync.Mutex
+	var firstErr error
+	errAt := size
+	parallelFor(size, 1, func(start, end int) {
+		index := unravelIndex(start, shape)
+		offset := offsetAt(dst, stride, index)
+		aOffset, bOffset := offsetAt(a, aStride, index), offsetAt(b, bStride, index)
+		for i := start; i < end; i++ {
+			// Apply the operation, keeping the error of the earliest element
+			if exact {
+				data.setInt(offset, op.exact(aData.getInt(aOffset), bData.getInt(bOffset)))
+			} else {
+				value, err := op.float(aData.get(aOffset), bData.get(bOffset))
+				if err != nil {
+					mu.Lock()
+					if i < errAt {
+						firstErr, errAt = err, i
+					}
+					mu.Unlock()
+					return
+				}
+				data.set(offset, value)
+			}
+
+			// Advance the index, carrying into the outer dimensions
+			for dim := len(shape) - 1; dim >= 0; dim-- {
+				index[dim]++
+				offset += stride[dim]
+				aOffset += aStride[dim]
+				bOffset += bStride[dim]
+				if index[dim] < shape[dim] {
+					break
+				}
+				offset -= stride[dim] * shape[dim]
+				aOffset -= aStride[dim] * shape[dim]
+				bOffset -= bStride[dim] * shape[dim]
+				index[dim] = 0
+			}
+		}
+	})
+	return firstErr
+}
+
+// detach returns x and its strides at dst's shape, copying x into new storage
+// when it shares memory with dst but is not read from the positions dst is
+// written to
+func detach(dst Operand, x Operand, stride []int) (Operand, []int) {
+	if !overlaps(dst.storage(), x.storage()) {
+		return x, stride
+	}
+	if x.Offset() == dst.Offset() && slices.Equal(stride, dst.Stride()) {
+		return x, stride
+	}
+	copied := newTensor(x.Shape(), gather(x.storage(), x.Shape(), x.Stride(), x.Offset()))
+	return copied, expandStrides(copied.Shape(), copied.Stride(), dst.Shape())
+}
+
+// addValues returns x + y
+func addValues(x, y float64) (float64, error) {
+	return x + y, nil
+}
+
+// subValues returns x - y
+func subValues(x, y float64) (float64, error) {
+	return x - y, nil
+}
+
+// mulValues returns x * y
+func mulValues(x, y float64) (float64, error) {
+	return x * y, nil
+}
+
+// addOp, subOp and mulOp are the arithmetic ops, integers wrap around on
+// overflow like Go integers
+var (
+	addOp = binaryOp{float: addValues, exact: func(x, y int64) int64 { return x + y }}
+	subOp = binaryOp{float: subValues, exact: func(x, y int64) int64 { return x - y }}
+	mulOp = binaryOp{float: mulValues, exact: func(x, y int64) int64 { return x * y }}
+)
+
+// add adds b to a elementwise
+func add(a Operand, b Operand) (*TensorStruct, error) {
+	// Perform element-wise addition
+	return elementwise(a, b, arithmeticType(a.DType(), b.DType()), addOp)
+}
+
+// sub subtracts b from a elementwise
+func sub(a Operand, b Operand) (*TensorStruct, error) {
+	// Perform element-wise subtraction
+	return elementwise(a, b, arithmeticType(a.DType(), b.DType()), subOp)
+}
+
+// mul multiplies a by b elementwise
+func mul(a Operand, b Operand) (*TensorStruct, error) {
+	// Perform element-wise multiplication
+	return elementwise(a, b, arithmeticType(a.DType(), b.DType()), mulOp)
+}
+
+// div divides a by b elementwise, dividing by zero follows the current division policy
+func div(a Operand, b Operand) (*TensorStruct, error) {
+	// Perform element-wise division
+	return divWith(a, b, CurrentDivisionPolicy())
+}
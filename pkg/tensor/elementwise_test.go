@@ -0,0 +1,75 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestMixedOperands tests arithmetic between tensors, views and broadcasts
+func TestMixedOperands(t *testing.T) {
+	matrix := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	row := mustNewTensor(t, []int{2}, []float64{10, 20})
+
+	transposed := matrix.Transpose()
+	rowBroadcast, err := NewBroadcast([]int{3, 2}, row)
+	if err != nil {
+		t.Fatalf("Failed to create broadcast: %v", err)
+	}
+	column, _ := matrix.Slice(All(), Index(1))
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+		expectErr     bool
+	}{
+		{"ViewAddBroadcast", func() (*TensorStruct, error) { return transposed.Add(rowBroadcast) }, []int{3, 2}, []float64{11, 24, 12, 25, 13, 26}, false},
+		{"BroadcastSubView", func() (*TensorStruct, error) { return rowBroadcast.Sub(transposed) }, []int{3, 2}, []float64{9, 16, 8, 15, 7, 14}, false},
+		{"TensorMulView", func() (*TensorStruct, error) { return row.Mul(column) }, []int{2}, []float64{20, 100}, false},
+		{"ViewDivTensor", func() (*TensorStruct, error) { return column.Div(row) }, []int{2}, []float64{0.2, 0.25}, false},
+		{"ViewAddImplicitBroadcast", func() (*TensorStruct, error) { return transposed.Add(row) }, []int{3, 2}, []float64{11, 24, 12, 25, 13, 26}, false},
+		{"IncompatibleView", func() (*TensorStruct, error) { return transposed.Add(matrix) }, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			if !almostEqual(tc.expectedData, result.Data()) {
+				t.Errorf("Expected data %v, got %v", tc.expectedData, result.Data())
+			}
+		})
+	}
+}
+
+// TestBroadcastOfView tests broadcasting views with offsets and strides
+func TestBroadcastOfView(t *testing.T) {
+	matrix := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	// The second column, as a 2x1 view with an offset
+	column, _ := matrix.Slice(All(), Range(1, 2))
+	broadcast, err := NewBroadcast([]int{2, 4}, column)
+	if err != nil {
+		t.Fatalf("Failed to create broadcast: %v", err)
+	}
+	checkEqual(t, "Offset", 1, broadcast.Offset())
+	checkEqual(t, "Stride", []int{3, 0}, broadcast.Stride())
+	checkEqual(t, "Data", []float64{2, 5}, broadcast.Data())
+
+	result, err := broadcast.ToTensor()
+	if err != nil {
+		t.Fatalf("Failed to convert broadcast: %v", err)
+	}
+	checkEqual(t, "Values", []float64{2, 2, 2, 2, 5, 5, 5, 5}, result.Data())
+
+	got, err := broadcast.At(1, 3)
+	if err != nil || got != 5 {
+		t.Errorf("At() = %v, %v, want 5", got, err)
+	}
+}
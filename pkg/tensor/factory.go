@@ -0,0 +1,189 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// maxFloat64s is the length of the largest float64 slice the runtime can
+// allocate, 2^48 bytes on 64-bit platforms and 2^32 bytes on 32-bit ones
+const maxFloat64s = 1 << (32 + bits.UintSize/64*16) / 8
+
+// validShape checks that a shape has no negative dimensions
+func validShape(shape []int) error {
+	for i, dim := range shape {
+		if dim < 0 {
+			return fmt.Errorf("invalid shape %v: negative dimension %d at axis %d", shape, dim, i)
+		}
+	}
+	return nil
+}
+
+// Zeros creates a float64 tensor of the given shape filled with zeros
+func Zeros(shape []int) (*TensorStruct, error) {
+	return Full(shape, 0)
+}
+
+// Ones creates a float64 tensor of the given shape filled with ones
+func Ones(shape []int) (*TensorStruct, error) {
+	return Full(shape, 1)
+}
+
+// Full creates a float64 tensor of the given shape filled with value
+func Full(shape []int, value float64) (*TensorStruct, error) {
+	return fullOf(shape, Float64, value)
+}
+
+// fullOf creates a tensor of the given shape and dtype filled with value
+func fullOf(shape []int, dtype DType, value float64) (*TensorStruct, error) {
+	// Check the shape
+	if err := validShape(shape); err != nil {
+		return nil, err
+	}
+
+	// Fill the data, new storage is already zeroed
+	data := newStorage(dtype, shapeSize(shape))
+	if value != 0 {
+		for i := 0; i < data.len(); i++ {
+			data.set(i, value)
+		}
+	}
+
+	// Return the tensor
+	return newTensor(shape, data), nil
+}
+
+// ZerosLike creates a tensor of zeros with the shape and dtype of t
+func ZerosLike(t *TensorStruct) *TensorStruct {
+	result, _ := fullOf(t.shape, t.DType(), 0)
+	return result
+}
+
+// OnesLike creates a tensor of ones with the shape and dtype of t
+func OnesLike(t *TensorStruct) *TensorStruct {
+	result, _ := fullOf(t.shape, t.DType(), 1)
+	return result
+}
+
+// FullLike creates a tensor filled with value with the shape and dtype of t
+func FullLike(t *TensorStruct, value float64) *TensorStruct {
+	result, _ := fullOf(t.shape, t.DType(), value)
+	return result
+}
+
+// Arange creates a 1D tensor of values from start up to, but not including,
+// stop, spaced step apart
+func Arange(start, stop, step float64) (*TensorStruct, error) {
+	// Check the bounds and the step
+	if math.IsNaN(start) || math.IsInf(start, 0) || math.IsNaN(stop) || math.IsInf(stop, 0) {
+		return nil, fmt.Errorf("invalid range: %v to %v", start, stop)
+	}
+	if step == 0 || math.IsNaN(step) || math.IsInf(step, 0) {
+		return nil, fmt.Errorf("invalid step: %v", step)
+	}
+
+	// Calculate the number of values, an empty range gives an empty tensor,
+	// and the data must fit in a slice the runtime can allocate
+	length := max(math.Ceil((stop-start)/step), 0)
+	if length > maxFloat64s {
+		return nil, fmt.Errorf("range %v to %v with step %v has too many values", start, stop, step)
+	}
+	n := int(length)
+
+	// Fill the data
+	data := allocFloat64s(n)
+	for i := range data {
+		data[i] = start + float64(i)*step
+	}
+
+	// Return the tensor
+	return newTensor([]int{n}, float64Storage(data)), nil
+}
+
+// Linspace creates a 1D tensor of num evenly spaced values from start to stop inclusive
+func Linspace(start, stop float64, num int) (*TensorStruct, error) {
+	// Check the number of values
+	if num < 0 {
+		return nil, fmt.Errorf("invalid number of values: %d", num)
+	}
+
+	// Calculate the spacing between values
+	step := 0.0
+	if num > 1 {
+		step = (stop - start) / float64(num-1)
+	}
+
+	// Fill the data, pinning the last value to stop exactly
+	data := allocFloat64s(num)
+	for i := range data {
+		data[i] = start + float64(i)*step
+	}
+	if num > 1 {
+		data[num-1] = stop
+	}
+
+	// Return the tensor
+	return newTensor([]int{num}, float64Storage(data)), nil
+}
+
+// Logspace creates a 1D tensor of num values spaced evenly on a log scale,
+// from base^start to base^stop inclusive
+func Logspace(start, stop float64, num int, base float64) (*TensorStruct, error) {
+	// Create the exponents
+	exponents, err := Linspace(start, stop, num)
+	if err != nil {
+		return nil, err
+	}
+
+	// Raise the base to each exponent
+	data := exponents.data.(float64Storage)
+	for i, v := range data {
+		data[i] = math.Pow(base, v)
+	}
+
+	// Return the tensor
+	return exponents, nil
+}
+
+// Eye creates an n x n float64 identity matrix
+func Eye(n int) (*TensorStruct, error) {
+	// Create the zero matrix
+	result, err := Zeros([]int{n, n})
+	if err != nil {
+		return nil, err
+	}
+
+	// Set the diagonal
+	for i := 0; i < n; i++ {
+		result.data.set(i*n+i, 1)
+	}
+
+	// Return the matrix
+	return result, nil
+}
+
+// Diag creates a square matrix with t on its diagonal when t is 1D, or
+// extracts the diagonal of t when t is 2D
+func Diag(t *TensorStruct) (*TensorStruct, error) {
+	switch t.Rank() {
+	case 1:
+		// Place the vector on the diagonal of a zero matrix
+		n := t.shape[0]
+		data := newStorage(t.DType(), n*n)
+		for i := 0; i < n; i++ {
+			copyElement(data, i*n+i, t.data, i*t.stride[0])
+		}
+		return newTensor([]int{n, n}, data), nil
+	case 2:
+		// Read the diagonal of the matrix
+		n := min(t.shape[0], t.shape[1])
+		data := newStorage(t.DType(), n)
+		for i := 0; i < n; i++ {
+			copyElement(data, i, t.data, i*t.stride[0]+i*t.stride[1])
+		}
+		return newTensor([]int{n}, data), nil
+	default:
+		return nil, fmt.Errorf("diag requires a 1D or 2D tensor, got %dD", t.Rank())
+	}
+}
@@ -0,0 +1,127 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+// TestFull tests the Zeros, Ones and Full factories
+func TestFull(t *testing.T) {
+	testCases := []struct {
+		name           string
+		create         func() (*TensorStruct, error)
+		expectedShape  []int
+		expectedStride []int
+		expectedData   []float64
+		expectErr      bool
+	}{
+		{"Zeros", func() (*TensorStruct, error) { return Zeros([]int{2, 2}) }, []int{2, 2}, []int{2, 1}, []float64{0, 0, 0, 0}, false},
+		{"Ones", func() (*TensorStruct, error) { return Ones([]int{3}) }, []int{3}, []int{1}, []float64{1, 1, 1}, false},
+		{"Full", func() (*TensorStruct, error) { return Full([]int{1, 2, 1}, 7) }, []int{1, 2, 1}, []int{2, 1, 1}, []float64{7, 7}, false},
+		{"Scalar", func() (*TensorStruct, error) { return Full([]int{}, 3) }, []int{}, []int{}, []float64{3}, false},
+		{"NegativeShape", func() (*TensorStruct, error) { return Zeros([]int{2, -1}) }, nil, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tensor, err := tc.create()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, tensor.Shape())
+			checkEqual(t, "Stride", tc.expectedStride, tensor.Stride())
+			checkEqual(t, "Data", tc.expectedData, tensor.Data())
+		})
+	}
+}
+
+// TestLike tests the *Like factories
+func TestLike(t *testing.T) {
+	source, _ := NewTensorOf([]int{2, 3}, []int32{1, 2, 3, 4, 5, 6})
+
+	zeros := ZerosLike(source)
+	checkEqual(t, "Zeros Shape", []int{2, 3}, zeros.Shape())
+	checkEqual(t, "Zeros DType", Int32, zeros.DType())
+	checkEqual(t, "Zeros Data", []float64{0, 0, 0, 0, 0, 0}, zeros.Data())
+
+	ones := OnesLike(source)
+	checkEqual(t, "Ones Data", []float64{1, 1, 1, 1, 1, 1}, ones.Data())
+
+	full := FullLike(source, 9)
+	checkEqual(t, "Full Data", []float64{9, 9, 9, 9, 9, 9}, full.Data())
+}
+
+// TestRanges tests the Arange, Linspace and Logspace factories
+func TestRanges(t *testing.T) {
+	testCases := []struct {
+		name         string
+		create       func() (*TensorStruct, error)
+		expectedData []float64
+		expectErr    bool
+	}{
+		{"Arange", func() (*TensorStruct, error) { return Arange(0, 5, 1) }, []float64{0, 1, 2, 3, 4}, false},
+		{"ArangeFractional", func() (*TensorStruct, error) { return Arange(0, 1, 0.25) }, []float64{0, 0.25, 0.5, 0.75}, false},
+		{"ArangeNegativeStep", func() (*TensorStruct, error) { return Arange(3, 0, -1) }, []float64{3, 2, 1}, false},
+		{"ArangeEmpty", func() (*TensorStruct, error) { return Arange(3, 0, 1) }, []float64{}, false},
+		{"ArangeEmptyHuge", func() (*TensorStruct, error) { return Arange(0, -1e300, 1) }, []float64{}, false},
+		{"ArangeZeroStep", func() (*TensorStruct, error) { return Arange(0, 1, 0) }, nil, true},
+		{"ArangeInfiniteStop", func() (*TensorStruct, error) { return Arange(0, math.Inf(1), 1) }, nil, true},
+		{"ArangeInfiniteStep", func() (*TensorStruct, error) { return Arange(0, 1, math.Inf(1)) }, nil, true},
+		{"ArangeTooLarge", func() (*TensorStruct, error) { return Arange(0, 1e300, 1) }, nil, true},
+		{"ArangeTooLargeToAllocate", func() (*TensorStruct, error) { return Arange(0, 1e15, 1) }, nil, true},
+		{"Linspace", func() (*TensorStruct, error) { return Linspace(0, 1, 5) }, []float64{0, 0.25, 0.5, 0.75, 1}, false},
+		{"LinspaceSingle", func() (*TensorStruct, error) { return Linspace(2, 4, 1) }, []float64{2}, false},
+		{"LinspaceNegative", func() (*TensorStruct, error) { return Linspace(0, 1, -1) }, nil, true},
+		{"Logspace", func() (*TensorStruct, error) { return Logspace(0, 3, 4, 10) }, []float64{1, 10, 100, 1000}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tensor, err := tc.create()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", []int{len(tc.expectedData)}, tensor.Shape())
+			if !almostEqual(tc.expectedData, tensor.Data()) {
+				t.Errorf("Expected data %v, got %v", tc.expectedData, tensor.Data())
+			}
+		})
+	}
+}
+
+// TestEyeAndDiag tests the Eye and Diag factories
+func TestEyeAndDiag(t *testing.T) {
+	eye, err := Eye(3)
+	if err != nil {
+		t.Fatalf("Eye: expected no error, got %v", err)
+	}
+	checkEqual(t, "Eye Shape", []int{3, 3}, eye.Shape())
+	checkEqual(t, "Eye Data", []float64{1, 0, 0, 0, 1, 0, 0, 0, 1}, eye.Data())
+
+	diag, err := Diag(mustNewTensor(t, []int{2}, []float64{4, 5}))
+	if err != nil {
+		t.Fatalf("Diag: expected no error, got %v", err)
+	}
+	checkEqual(t, "Diag Shape", []int{2, 2}, diag.Shape())
+	checkEqual(t, "Diag Data", []float64{4, 0, 0, 5}, diag.Data())
+
+	extracted, err := Diag(mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6}))
+	if err != nil {
+		t.Fatalf("Diag: expected no error, got %v", err)
+	}
+	checkEqual(t, "Extracted Data", []float64{1, 5}, extracted.Data())
+
+	if _, err := Diag(mustNewTensor(t, []int{1, 1, 1}, []float64{1})); err == nil {
+		t.Error("Expected error for 3D diag, got nil")
+	}
+
+	if _, err := Eye(-1); err == nil {
+		t.Error("Expected error for negative eye size, got nil")
+	}
+}
@@ -0,0 +1,344 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+)
+
+// indexValues returns the elements of idx in row-major order as indices into
+// an axis of the given size, negative indices count from the end of the axis,
+// a negative axis checks the indices against the size of a flattened tensor
+func indexValues(idx Operand, size int, axis int) ([]int, error) {
+	if idx.DType().IsFloat() || idx.DType() == Bool {
+		return nil, fmt.Errorf("index tensor must have an integer dtype, got %v", idx.DType())
+	}
+
+	// Read and check each index
+	data := contiguousStorage(idx)
+	values := make([]int, shapeSize(idx.Shape()))
+	for i := range values {
+		value := data.getInt(i)
+		if value < -int64(size) || value >= int64(size) {
+			position := unravelIndex(i, idx.Shape())
+			if axis < 0 {
+				return nil, fmt.Errorf("index %d at position %v out of bounds for size %d", value, position, size)
+			}
+			return nil, fmt.Errorf("index %d at position %v out of bounds for axis %d with size %d", value, position, axis, size)
+		}
+		if value < 0 {
+			value += int64(size)
+		}
+		values[i] = int(value)
+	}
+	return values, nil
+}
+
+// checkStore checks that values of the dtype can be stored in x without
+// losing their fractional part
+func checkStore(x Operand, dtype DType) error {
+	if dtype.IsFloat() && !x.DType().IsFloat() {
+		return fmt.Errorf("cannot store %v values in %v tensor", dtype, x.DType())
+	}
+	return nil
+}
+
+// checkGather checks idx can index x along axis for gather and scatter, idx
+// must have the rank of x and be no larger than x along the other axes
+func checkGather(x Operand, axis int, idx Operand) error {
+	if len(idx.Shape()) != len(x.Shape()) {
+		return fmt.Errorf("index tensor has rank %d, expected %d", len(idx.Shape()), len(x.Shape()))
+	}
+	for i, dim := range idx.Shape() {
+		if i != axis && dim > x.Shape()[i] {
+			return fmt.Errorf("index tensor has size %d at axis %d, larger than %d", dim, i, x.Shape()[i])
+		}
+	}
+	return nil
+}
+
+// indexSelect returns the elements of x at the 1D indices idx along axis
+func indexSelect(x Operand, axis int, idx Operand) (*TensorStruct, error) {
+	normalized, err := normalizeAxis(axis, len(x.Shape()))
+	if err != nil {
+		return nil, err
+	}
+	if len(idx.Shape()) != 1 {
+		return nil, fmt.Errorf("index select requires a 1D index tensor, got %dD", len(idx.Shape()))
+	}
+	values, err := indexValues(idx, x.Shape()[normalized], normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read each element, replacing its position along axis with the index
+	shape := append([]int{}, x.Shape()...)
+	shape[normalized] = len(values)
+	result := newStorage(x.DType(), shapeSize(shape))
+	stride, data := x.Stride(), x.storage()
+	parallelFor(result.len(), 1, func(start, end int) {
+		pos := unravelIndex(start, shape)
+		src := make([]int, len(shape))
+		for i := start; i < end; i++ {
+			copy(src, pos)
+			src[normalized] = values[pos[normalized]]
+			copyElement(result, i, data, offsetAt(x, stride, src))
+			nextIndex(pos, shape)
+		}
+	})
+	return newTensor(shape, result), nil
+}
+
+// gatherAxis returns the elements of x picked along axis by idx, the result
+// has the shape of idx and its element at a position is x at that position
+// with the position along axis replaced by the element of idx
+func gatherAxis(x Operand, axis int, idx Operand) (*TensorStruct, error) {
+	normalized, err := normalizeAxis(axis, len(x.Shape()))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGather(x, normalized, idx); err != nil {
+		return nil, err
+	}
+	values, err := indexValues(idx, x.Shape()[normalized], normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	// Read each element, replacing its position along axis with the index
+	shape := idx.Shape()
+	result := newStorage(x.DType(), len(values))
+	stride, data := x.Stride(), x.storage()
+	parallelFor(result.len(), 1, func(start, end int) {
+		pos := unravelIndex(start, shape)
+		src := make([]int, len(shape))
+		for i := start; i < end; i++ {
+			copy(src, pos)
+			src[normalized] = values[i]
+			copyElement(result, i, data, offsetAt(x, stride, src))
+			nextIndex(pos, shape)
+		}
+	})
+	return newTensor(slices.Clone(shape), result), nil
+}
+
+// scatter returns a copy of x with the elements of src written along axis at
+// the positions given by idx, the reverse of gatherAxis, src broadcasts to the
+// shape of idx, with accumulate the elements are added instead and repeated
+// indices add up, otherwise the last write to a position wins
+func scatter(x Operand, axis int, idx Operand, src Operand, accumulate bool) (*TensorStruct, error) {
+	normalized, err := normalizeAxis(axis, len(x.Shape()))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkGather(x, normalized, idx); err != nil {
+		return nil, err
+	}
+	if err := checkStore(x, src.DType()); err != nil {
+		return nil, err
+	}
+	values, err := indexValues(idx, x.Shape()[normalized], normalized)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check src broadcasts to the shape of idx
+	shape := idx.Shape()
+	if broadcast, err := broadcastShapes(src.Shape(), shape); err != nil || !slices.Equal(broadcast, shape) {
+		return nil, fmt.Errorf("source shape %v does not broadcast to index shape %v", src.Shape(), shape)
+	}
+	srcStride := expandStrides(src.Shape(), src.Stride(), shape)
+
+	// Write each element of src into a copy of x, in order so the result is
+	// the same for repeated indices
+	result := newTensor(slices.Clone(x.Shape()), gather(x.storage(), x.Shape(), x.Stride(), x.Offset()))
+	pos := make([]int, len(shape))
+	dst := make([]int, len(shape))
+	for _, value := range values {
+		copy(dst, pos)
+		dst[normalized] = value
+		offset := offsetAt(result, result.stride, dst)
+		from := offsetAt(src, srcStride, pos)
+		switch {
+		case !accumulate:
+			copyElement(result.data, offset, src.storage(), from)
+		case isExact(result.DType(), src.DType()):
+			result.data.setInt(offset, result.data.getInt(offset)+src.storage().getInt(from))
+		default:
+			result.data.set(offset, result.data.get(offset)+src.storage().get(from))
+		}
+		nextIndex(pos, shape)
+	}
+	return result, nil
+}
+
+// take returns the elements of x at the flat indices idx, counting in
+// row-major order, the result has the shape of idx
+func take(x Operand, idx Operand) (*TensorStruct, error) {
+	values, err := indexValues(idx, shapeSize(x.Shape()), -1)
+	if err != nil {
+		return nil, err
+	}
+	result := newStorage(x.DType(), len(values))
+	for i, value := range values {
+		copyElement(result, i, x.storage(), offsetAt(x, x.Stride(), unravelIndex(value, x.Shape())))
+	}
+	return newTensor(slices.Clone(idx.Shape()), result), nil
+}
+
+// put writes the elements of values into x at the flat indices idx, counting
+// in row-major order, values broadcasts to the shape of idx
+func put(x Operand, idx Operand, values Operand) error {
+	if err := checkStore(x, values.DType()); err != nil {
+		return err
+	}
+	indices, err := indexValues(idx, shapeSize(x.Shape()), -1)
+	if err != nil {
+		return err
+	}
+
+	// Check values broadcasts to the shape of idx
+	shape := idx.Shape()
+	if broadcast, err := broadcastShapes(values.Shape(), shape); err != nil || !slices.Equal(broadcast, shape) {
+		return fmt.Errorf("values shape %v does not broadcast to index shape %v", values.Shape(), shape)
+	}
+	valueStride := expandStrides(values.Shape(), values.Stride(), shape)
+
+	// Write each value in order, the last write to a position wins
+	pos := make([]int, len(shape))
+	for _, index := range indices {
+		copyElement(x.storage(), offsetAt(x, x.Stride(), unravelIndex(index, x.Shape())), values.storage(), offsetAt(values, valueStride, pos))
+		nextIndex(pos, shape)
+	}
+	return nil
+}
+
+// indexWith indexes the leading axes of x with integer index tensors, one per
+// axis, the index tensors broadcast together and the result has their
+// broadcast shape followed by the axes of x that are not indexed
+func indexWith(x Operand, indices ...Operand) (*TensorStruct, error) {
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no index tensors")
+	}
+	if len(indices) > len(x.Shape()) {
+		return nil, fmt.Errorf("too many index tensors for rank %d: got %d", len(x.Shape()), len(indices))
+	}
+
+	// Check the indices of each axis and broadcast their shapes
+	values := make([][]int, len(indices))
+	indexShape := []int{}
+	for axis, idx := range indices {
+		var err error
+		values[axis], err = indexValues(idx, x.Shape()[axis], axis)
+		if err != nil {
+			return nil, err
+		}
+		indexShape, err = broadcastShapes(indexShape, idx.Shape())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The values of each index tensor are packed, read them at the broadcast shape
+	strides := make([][]int, len(indices))
+	for axis, idx := range indices {
+		strides[axis] = expandStrides(idx.Shape(), computeStrides(idx.Shape()), indexShape)
+	}
+
+	// Read each element, the leading part of its position picks the indices
+	rest := x.Shape()[len(indices):]
+	shape := append(slices.Clone(indexShape), rest...)
+	result := newStorage(x.DType(), shapeSize(shape))
+	stride, data := x.Stride(), x.storage()
+	parallelFor(result.len(), 1, func(start, end int) {
+		pos := unravelIndex(start, shape)
+		src := make([]int, len(x.Shape()))
+		for i := start; i < end; i++ {
+			for axis := range indices {
+				flat := 0
+				for j, v := range pos[:len(indexShape)] {
+					flat += v * strides[axis][j]
+				}
+				src[axis] = values[axis][flat]
+			}
+			copy(src[len(indices):], pos[len(indexShape):])
+			copyElement(result, i, data, offsetAt(x, stride, src))
+			nextIndex(pos, shape)
+		}
+	})
+	return newTensor(shape, result), nil
+}
+
+// IndexSelect returns the elements of the tensor at the 1D indices idx along axis
+func (t *TensorStruct) IndexSelect(axis int, idx Operand) (*TensorStruct, error) {
+	return indexSelect(t, axis, idx)
+}
+
+// Gather returns the elements of the tensor picked along axis by idx
+func (t *TensorStruct) Gather(axis int, idx Operand) (*TensorStruct, error) {
+	return gatherAxis(t, axis, idx)
+}
+
+// Scatter returns a copy of the tensor with the elements of src written along
+// axis at the positions given by idx
+func (t *TensorStruct) Scatter(axis int, idx Operand, src Operand) (*TensorStruct, error) {
+	return scatter(t, axis, idx, src, false)
+}
+
+// ScatterAdd returns a copy of the tensor with the elements of src added along
+// axis at the positions given by idx
+func (t *TensorStruct) ScatterAdd(axis int, idx Operand, src Operand) (*TensorStruct, error) {
+	return scatter(t, axis, idx, src, true)
+}
+
+// Take returns the elements of the tensor at the flat indices idx
+func (t *TensorStruct) Take(idx Operand) (*TensorStruct, error) {
+	return take(t, idx)
+}
+
+// Put writes values into the tensor at the flat indices idx
+func (t *TensorStruct) Put(idx Operand, values Operand) error {
+	return put(t, idx, values)
+}
+
+// IndexWith indexes the leading axes of the tensor with integer index tensors
+func (t *TensorStruct) IndexWith(indices ...Operand) (*TensorStruct, error) {
+	return indexWith(t, indices...)
+}
+
+// IndexSelect returns the elements of the view at the 1D indices idx along axis
+func (v *ViewStruct) IndexSelect(axis int, idx Operand) (*TensorStruct, error) {
+	return indexSelect(v, axis, idx)
+}
+
+// Gather returns the elements of the view picked along axis by idx
+func (v *ViewStruct) Gather(axis int, idx Operand) (*TensorStruct, error) {
+	return gatherAxis(v, axis, idx)
+}
+
+// Scatter returns a copy of the view with the elements of src written along
+// axis at the positions given by idx
+func (v *ViewStruct) Scatter(axis int, idx Operand, src Operand) (*TensorStruct, error) {
+	return scatter(v, axis, idx, src, false)
+}
+
+// ScatterAdd returns a copy of the view with the elements of src added along
+// axis at the positions given by idx
+func (v *ViewStruct) ScatterAdd(axis int, idx Operand, src Operand) (*TensorStruct, error) {
+	return scatter(v, axis, idx, src, true)
+}
+
+// Take returns the elements of the view at the flat indices idx
+func (v *ViewStruct) Take(idx Operand) (*TensorStruct, error) {
+	return take(v, idx)
+}
+
+// Put writes values into the view at the flat indices idx, writing through
+// to the underlying tensor
+func (v *ViewStruct) Put(idx Operand, values Operand) error {
+	return put(v, idx, values)
+}
+
+// IndexWith indexes the leading axes of the view with integer index tensors
+func (v *ViewStruct) IndexWith(indices ...Operand) (*TensorStruct, error) {
+	return indexWith(v, indices...)
+}
@@ -0,0 +1,121 @@
+package tensor
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestIndexing tests the integer index based operations
+func TestIndexing(t *testing.T) {
+	x := mustNewTensor(t, []int{3, 3}, []float64{1, 2, 3, 4, 5, 6, 7, 8, 9})
+	rows, _ := NewTensorOf([]int{2}, []int64{2, 0})
+	picks, _ := NewTensorOf([]int{3, 1}, []int64{0, 2, -1})
+	flat, _ := NewTensorOf([]int{2, 2}, []int32{0, 4, 8, -2})
+	columns, _ := NewTensorOf([]int{2}, []int64{1, 2})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+	}{
+		{"IndexSelectRows", func() (*TensorStruct, error) { return x.IndexSelect(0, rows) }, []int{2, 3}, []float64{7, 8, 9, 1, 2, 3}},
+		{"IndexSelectColumns", func() (*TensorStruct, error) { return x.IndexSelect(-1, rows) }, []int{3, 2}, []float64{3, 1, 6, 4, 9, 7}},
+		{"Gather", func() (*TensorStruct, error) { return x.Gather(1, picks) }, []int{3, 1}, []float64{1, 6, 9}},
+		{"Take", func() (*TensorStruct, error) { return x.Take(flat) }, []int{2, 2}, []float64{1, 5, 9, 8}},
+		{"TakeView", func() (*TensorStruct, error) { return x.Transpose().Take(flat) }, []int{2, 2}, []float64{1, 5, 9, 6}},
+		{"IndexWithPairs", func() (*TensorStruct, error) { return x.IndexWith(rows, columns) }, []int{2}, []float64{8, 3}},
+		{"IndexWithRows", func() (*TensorStruct, error) { return x.IndexWith(rows) }, []int{2, 3}, []float64{7, 8, 9, 1, 2, 3}},
+		{"IndexWithBroadcast", func() (*TensorStruct, error) { return x.IndexWith(picks, columns) }, []int{3, 2}, []float64{2, 3, 8, 9, 8, 9}},
+		{"Scatter", func() (*TensorStruct, error) { return x.Scatter(1, picks, NewScalar(0)) }, []int{3, 3}, []float64{0, 2, 3, 4, 5, 0, 7, 8, 0}},
+		{"ScatterAdd", func() (*TensorStruct, error) {
+			return x.ScatterAdd(0, mustIndex(t, []int{1, 3}, []int64{1, 1, 1}), mustNewTensor(t, []int{1, 3}, []float64{10, 20, 30}))
+		}, []int{3, 3}, []float64{1, 2, 3, 14, 25, 36, 7, 8, 9}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			checkEqual(t, "Data", tc.expectedData, result.Data())
+		})
+	}
+
+	// Scatter and ScatterAdd leave the tensor unchanged
+	checkEqual(t, "Unchanged", []float64{1, 2, 3, 4, 5, 6, 7, 8, 9}, x.Data())
+}
+
+// mustIndex creates an Int64 index tensor or fails the test
+func mustIndex(t *testing.T, shape []int, data []int64) *TensorStruct {
+	idx, err := NewTensorOf(shape, data)
+	if err != nil {
+		t.Fatalf("Failed to create index tensor: %v", err)
+	}
+	return idx
+}
+
+// TestScatterAddRepeated tests that repeated indices accumulate, as in a histogram
+func TestScatterAddRepeated(t *testing.T) {
+	counts, _ := Zeros([]int{4})
+	labels := mustIndex(t, []int{6}, []int64{0, 2, 2, 3, 2, 0})
+
+	histogram, err := counts.ScatterAdd(0, labels, NewScalar(1))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Histogram", []float64{2, 0, 3, 1}, histogram.Data())
+}
+
+// TestPut tests writing values at flat indices in place
+func TestPut(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	if err := x.Put(mustIndex(t, []int{2}, []int64{0, -1}), mustNewTensor(t, []int{2}, []float64{10, 60})); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Put", []float64{10, 2, 3, 4, 5, 60}, x.Data())
+
+	// Flat indices of a view count in the view's order
+	if err := x.Transpose().Put(mustIndex(t, []int{1}, []int64{1}), NewScalar(-4)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Put View", []float64{10, 2, 3, -4, 5, 60}, x.Data())
+}
+
+// TestIndexingErrors tests that invalid indices are reported
+func TestIndexingErrors(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	ints, _ := NewTensorOf([]int{2}, []int64{1, 2})
+
+	testCases := []struct {
+		name     string
+		apply    func() error
+		expected string
+	}{
+		{"OutOfBounds", func() error { _, err := x.IndexSelect(0, mustIndex(t, []int{3}, []int64{0, 1, 2})); return err }, "index 2 at position [2]"},
+		{"NegativeOutOfBounds", func() error { _, err := x.Gather(1, mustIndex(t, []int{2, 1}, []int64{0, -4})); return err }, "index -4 at position [1 0]"},
+		{"TakeOutOfBounds", func() error { _, err := x.Take(mustIndex(t, []int{1}, []int64{6})); return err }, "index 6 at position [0]"},
+		{"LargeOutOfBounds", func() error { _, err := x.Take(mustIndex(t, []int{1}, []int64{1<<60 + 1})); return err }, "index 1152921504606846977 at position [0]"},
+		{"PutOutOfBounds", func() error { return x.Put(mustIndex(t, []int{1}, []int64{-7}), NewScalar(0)) }, "index -7"},
+		{"FloatIndex", func() error { _, err := x.IndexSelect(0, mustNewTensor(t, []int{1}, []float64{0})); return err }, "integer dtype"},
+		{"GatherRank", func() error { _, err := x.Gather(0, ints); return err }, "rank"},
+		{"IndexWithTooMany", func() error { _, err := x.IndexWith(ints, ints, ints); return err }, "too many"},
+		{"IndexWithShapes", func() error {
+			_, err := x.IndexWith(mustIndex(t, []int{2}, []int64{0, 1}), mustIndex(t, []int{3}, []int64{0, 0, 0}))
+			return err
+		}, "incompatible"},
+		{"ScatterFloatIntoInt", func() error { _, err := ints.Scatter(0, ints, NewScalar(0.5)); return err }, "cannot store"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.apply()
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
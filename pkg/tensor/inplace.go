@@ -0,0 +1,135 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// checkDestination checks dst can hold the result of an op between a and b,
+// dst must have the broadcast shape of a and b and a floating point result
+// cannot be stored in an integer or boolean dst
+func checkDestination(dst Operand, a Operand, b Operand, dtype DType) error {
+	shape, err := broadcastShapes(a.Shape(), b.Shape())
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(shape, dst.Shape()) {
+		return fmt.Errorf("destination shape %v does not match result shape %v", dst.Shape(), shape)
+	}
+	if dtype.IsFloat() && !dst.DType().IsFloat() {
+		return fmt.Errorf("cannot store %v result in %v destination", dtype, dst.DType())
+	}
+	return nil
+}
+
+// inPlace applies op to every pair of elements of x and other, writing the
+// results back into x, other must broadcast to the shape of x
+func inPlace(x Operand, other Operand, dtype DType, op binaryOp) error {
+	if err := checkDestination(x, x, other, dtype); err != nil {
+		return err
+	}
+	return elementwiseTo(x, x, other, op)
+}
+
+// into applies op to every pair of elements of a and b, writing the results
+// into dst, which must have the broadcast shape of a and b
+func into(dst *TensorStruct, a Operand, b Operand, dtype DType, op binaryOp) error {
+	if dst == nil {
+		return fmt.Errorf("nil destination tensor")
+	}
+	if err := checkDestination(dst, a, b, dtype); err != nil {
+		return err
+	}
+	return elementwiseTo(dst, a, b, op)
+}
+
+// addScaled adds alpha times other to x in place, alpha is treated as a float
+// unless it is a whole number
+func addScaled(x Operand, alpha float64, other Operand) error {
+	dtype := arithmeticType(x.DType(), other.DType())
+	if alpha != math.Trunc(alpha) {
+		dtype = floatType(dtype)
+	}
+	return inPlace(x, other, dtype, binaryOp{
+		float: func(a, b float64) (float64, error) {
+			return a + alpha*b, nil
+		},
+		exact: func(a, b int64) int64 {
+			return a + int64(alpha)*b
+		},
+	})
+}
+
+// AddInto writes a + b into dst without allocating
+func AddInto(dst *TensorStruct, a Operand, b Operand) error {
+	return into(dst, a, b, arithmeticType(a.DType(), b.DType()), addOp)
+}
+
+// SubInto writes a - b into dst without allocating
+func SubInto(dst *TensorStruct, a Operand, b Operand) error {
+	return into(dst, a, b, arithmeticType(a.DType(), b.DType()), subOp)
+}
+
+// MulInto writes a * b into dst without allocating
+func MulInto(dst *TensorStruct, a Operand, b Operand) error {
+	return into(dst, a, b, arithmeticType(a.DType(), b.DType()), mulOp)
+}
+
+// DivInto writes a / b into dst without allocating, dividing by zero follows
+// the current division policy and when it fails part of dst may already be written
+func DivInto(dst *TensorStruct, a Operand, b Operand) error {
+	return into(dst, a, b, divisionType(a.DType(), b.DType()), floatOp(CurrentDivisionPolicy().divide))
+}
+
+// AddInPlace adds other to the tensor, other must broadcast to the tensor's shape
+func (t *TensorStruct) AddInPlace(other Operand) error {
+	return inPlace(t, other, arithmeticType(t.DType(), other.DType()), addOp)
+}
+
+// SubInPlace subtracts other from the tensor, other must broadcast to the tensor's shape
+func (t *TensorStruct) SubInPlace(other Operand) error {
+	return inPlace(t, other, arithmeticType(t.DType(), other.DType()), subOp)
+}
+
+// MulInPlace multiplies the tensor by other, other must broadcast to the tensor's shape
+func (t *TensorStruct) MulInPlace(other Operand) error {
+	return inPlace(t, other, arithmeticType(t.DType(), other.DType()), mulOp)
+}
+
+// DivInPlace divides the tensor by other, other must broadcast to the tensor's
+// shape, when the division fails part of the tensor may already be written
+func (t *TensorStruct) DivInPlace(other Operand) error {
+	return inPlace(t, other, divisionType(t.DType(), other.DType()), floatOp(CurrentDivisionPolicy().divide))
+}
+
+// AddScaled adds alpha times other to the tensor, other must broadcast to the tensor's shape
+func (t *TensorStruct) AddScaled(alpha float64, other Operand) error {
+	return addScaled(t, alpha, other)
+}
+
+// AddInPlace adds other to the view, writing through to the underlying tensor
+func (v *ViewStruct) AddInPlace(other Operand) error {
+	return inPlace(v, other, arithmeticType(v.DType(), other.DType()), addOp)
+}
+
+// SubInPlace subtracts other from the view, writing through to the underlying tensor
+func (v *ViewStruct) SubInPlace(other Operand) error {
+	return inPlace(v, other, arithmeticType(v.DType(), other.DType()), subOp)
+}
+
+// MulInPlace multiplies the view by other, writing through to the underlying tensor
+func (v *ViewStruct) MulInPlace(other Operand) error {
+	return inPlace(v, other, arithmeticType(v.DType(), other.DType()), mulOp)
+}
+
+// DivInPlace divides the view by other, writing through to the underlying
+// tensor, when the division fails part of the view may already be written
+func (v *ViewStruct) DivInPlace(other Operand) error {
+	return inPlace(v, other, divisionType(v.DType(), other.DType()), floatOp(CurrentDivisionPolicy().divide))
+}
+
+// AddScaled adds alpha times other to the view, writing through to the underlying tensor
+func (v *ViewStruct) AddScaled(alpha float64, other Operand) error {
+	return addScaled(v, alpha, other)
+}
@@ -0,0 +1,115 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestInPlace tests the in-place arithmetic operations
+func TestInPlace(t *testing.T) {
+	testCases := []struct {
+		name         string
+		apply        func(x *TensorStruct) error
+		expectedData []float64
+		expectErr    bool
+	}{
+		{"AddInPlace", func(x *TensorStruct) error { return x.AddInPlace(NewScalar(1)) }, []float64{2, 3, 4, 5}, false},
+		{"SubInPlace", func(x *TensorStruct) error { return x.SubInPlace(mustNewTensor(t, []int{2}, []float64{1, 2})) }, []float64{0, 0, 2, 2}, false},
+		{"MulInPlace", func(x *TensorStruct) error { return x.MulInPlace(mustNewTensor(t, []int{2, 1}, []float64{2, 3})) }, []float64{2, 4, 9, 12}, false},
+		{"DivInPlace", func(x *TensorStruct) error { return x.DivInPlace(NewScalar(2)) }, []float64{0.5, 1, 1.5, 2}, false},
+		{"AddScaled", func(x *TensorStruct) error { return x.AddScaled(-0.5, mustNewTensor(t, []int{2}, []float64{2, 4})) }, []float64{0, 0, 2, 2}, false},
+		{"SelfAlias", func(x *TensorStruct) error { return x.MulInPlace(x) }, []float64{1, 4, 9, 16}, false},
+		{"TransposedAlias", func(x *TensorStruct) error { return x.AddInPlace(x.Transpose()) }, []float64{2, 5, 5, 8}, false},
+		{"Expanding", func(x *TensorStruct) error {
+			return x.AddInPlace(mustNewTensor(t, []int{3, 2, 2}, make([]float64, 12)))
+		}, []float64{1, 2, 3, 4}, true},
+		{"DivisionByZero", func(x *TensorStruct) error { return x.DivInPlace(NewScalar(0)) }, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			x := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+			err := tc.apply(x)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if tc.expectedData != nil {
+				checkEqual(t, "Data", tc.expectedData, x.Data())
+			}
+		})
+	}
+}
+
+// TestInPlaceView tests in-place operations writing through a view
+func TestInPlaceView(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	column, _ := x.Slice(All(), Index(1))
+
+	if err := column.MulInPlace(NewScalar(10)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Data", []float64{1, 20, 3, 4, 50, 6}, x.Data())
+
+	if err := column.AddScaled(2, mustNewTensor(t, []int{2}, []float64{1, 1})); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Data", []float64{1, 22, 3, 4, 52, 6}, x.Data())
+}
+
+// TestInPlaceDType tests that float results are not stored in integer tensors
+func TestInPlaceDType(t *testing.T) {
+	ints, _ := NewTensorOf([]int{2}, []int64{4, 6})
+
+	if err := ints.AddInPlace(NewScalar(1.5)); err == nil {
+		t.Error("AddInPlace: expected error storing a float result in an integer tensor")
+	}
+	if err := ints.DivInPlace(ints); err == nil {
+		t.Error("DivInPlace: expected error storing a float result in an integer tensor")
+	}
+	if err := ints.AddScaled(0.5, ints); err == nil {
+		t.Error("AddScaled: expected error for a fractional alpha on an integer tensor")
+	}
+
+	small, _ := NewTensorOf([]int{2}, []int32{1, 2})
+	if err := ints.AddScaled(2, small); err != nil {
+		t.Fatalf("AddScaled: expected no error, got %v", err)
+	}
+	checkEqual(t, "Data", []float64{6, 10}, ints.Data())
+	checkEqual(t, "DType", Int64, ints.DType())
+}
+
+// TestInto tests writing results into a preallocated tensor
+func TestInto(t *testing.T) {
+	a := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+	b := mustNewTensor(t, []int{2}, []float64{10, 20})
+	dst, _ := Zeros([]int{2, 2})
+
+	testCases := []struct {
+		name         string
+		apply        func(dst *TensorStruct) error
+		expectedData []float64
+	}{
+		{"AddInto", func(dst *TensorStruct) error { return AddInto(dst, a, b) }, []float64{11, 22, 13, 24}},
+		{"SubInto", func(dst *TensorStruct) error { return SubInto(dst, a, b) }, []float64{-9, -18, -7, -16}},
+		{"MulInto", func(dst *TensorStruct) error { return MulInto(dst, a, b) }, []float64{10, 40, 30, 80}},
+		{"DivInto", func(dst *TensorStruct) error { return DivInto(dst, b, a) }, []float64{10, 10, 10.0 / 3, 5}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := tc.apply(dst); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if !almostEqual(tc.expectedData, dst.Data()) {
+				t.Errorf("Expected data %v, got %v", tc.expectedData, dst.Data())
+			}
+		})
+	}
+
+	wrong, _ := Zeros([]int{4})
+	if err := AddInto(wrong, a, b); err == nil {
+		t.Error("Expected error for a destination of the wrong shape")
+	}
+	if err := AddInto(nil, a, b); err == nil {
+		t.Error("Expected error for a nil destination")
+	}
+}
@@ -0,0 +1,213 @@
+package tensor
+
+import (
+	"fmt"
+)
+
+// assign copies the elements of src into dst, which have the same shape
+func assign(dst Operand, src Operand) {
+	shape := src.Shape()
+	dstStride, srcStride := dst.Stride(), src.Stride()
+	dstData, srcData := dst.storage(), src.storage()
+	parallelFor(shapeSize(shape), 1, func(start, end int) {
+		idx := unravelIndex(start, shape)
+		for i := start; i < end; i++ {
+			copyElement(dstData, offsetAt(dst, dstStride, idx), srcData, offsetAt(src, srcStride, idx))
+			nextIndex(idx, shape)
+		}
+	})
+}
+
+// Concat joins the tensors along an existing axis, the tensors must have the
+// same shape except along axis and the result has their promoted dtype
+func Concat(axis int, tensors ...Operand) (*TensorStruct, error) {
+	if len(tensors) == 0 {
+		return nil, fmt.Errorf("no tensors to concatenate")
+	}
+	first := tensors[0].Shape()
+	if len(first) == 0 {
+		return nil, fmt.Errorf("cannot concatenate scalars")
+	}
+	normalized, err := normalizeAxis(axis, len(first))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the shapes and compute the shape and dtype of the result
+	shape := append([]int{}, first...)
+	shape[normalized] = 0
+	dtype := tensors[0].DType()
+	for i, x := range tensors {
+		if len(x.Shape()) != len(first) {
+			return nil, fmt.Errorf("tensor %d has rank %d, expected %d", i, len(x.Shape()), len(first))
+		}
+		for j, dim := range x.Shape() {
+			if j != normalized && dim != first[j] {
+				return nil, fmt.Errorf("tensor %d has size %d at axis %d, expected %d", i, dim, j, first[j])
+			}
+		}
+		shape[normalized] += x.Shape()[normalized]
+		dtype = promoteTypes(dtype, x.DType())
+	}
+
+	// Copy each tensor into its block of the result
+	result := newTensor(shape, newStorage(dtype, shapeSize(shape)))
+	start := 0
+	for _, x := range tensors {
+		assign(&ViewStruct{shape: x.Shape(), stride: result.stride, offset: start * result.stride[normalized], tensor: result}, x)
+		start += x.Shape()[normalized]
+	}
+	return result, nil
+}
+
+// Stack joins the tensors along a new axis inserted at axis, the tensors must
+// have the same shape and the result has their promoted dtype
+func Stack(axis int, tensors ...Operand) (*TensorStruct, error) {
+	if len(tensors) == 0 {
+		return nil, fmt.Errorf("no tensors to stack")
+	}
+	first := tensors[0].Shape()
+	normalized, err := normalizeAxis(axis, len(first)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the shapes and compute the shape and dtype of the result
+	dtype := tensors[0].DType()
+	for i, x := range tensors {
+		if len(x.Shape()) != len(first) {
+			return nil, fmt.Errorf("tensor %d has rank %d, expected %d", i, len(x.Shape()), len(first))
+		}
+		for j, dim := range x.Shape() {
+			if dim != first[j] {
+				return nil, fmt.Errorf("tensor %d has size %d at axis %d, expected %d", i, dim, j, first[j])
+			}
+		}
+		dtype = promoteTypes(dtype, x.DType())
+	}
+	shape := append(append(append([]int{}, first[:normalized]...), len(tensors)), first[normalized:]...)
+
+	// Copy each tensor into its slice of the new axis
+	result := newTensor(shape, newStorage(dtype, shapeSize(shape)))
+	stride := append(append([]int{}, result.stride[:normalized]...), result.stride[normalized+1:]...)
+	for i, x := range tensors {
+		assign(&ViewStruct{shape: first, stride: stride, offset: i * result.stride[normalized], tensor: result}, x)
+	}
+	return result, nil
+}
+
+// HStack joins the tensors column-wise, 1D tensors are joined along their
+// only axis and others along axis 1
+func HStack(tensors ...Operand) (*TensorStruct, error) {
+	if len(tensors) > 0 && len(tensors[0].Shape()) == 1 {
+		return Concat(0, tensors...)
+	}
+	return Concat(1, tensors...)
+}
+
+// VStack joins the tensors row-wise along axis 0, 1D tensors of length n are
+// treated as rows of shape [1, n]
+func VStack(tensors ...Operand) (*TensorStruct, error) {
+	rows := make([]Operand, len(tensors))
+	for i, x := range tensors {
+		rows[i] = x
+		if len(x.Shape()) == 1 {
+			row, err := NewBroadcast([]int{1, x.Shape()[0]}, x)
+			if err != nil {
+				return nil, err
+			}
+			rows[i] = row
+		}
+	}
+	return Concat(0, rows...)
+}
+
+// Split partitions the view along axis into views with the given sizes, which
+// must add up to the size of the axis, the views share the view's data
+func (v *ViewStruct) Split(sizes []int, axis int) ([]*ViewStruct, error) {
+	normalized, err := normalizeAxis(axis, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the sizes cover the axis
+	total := 0
+	for i, size := range sizes {
+		if size < 0 {
+			return nil, fmt.Errorf("invalid split size %d at index %d", size, i)
+		}
+		total += size
+	}
+	if total != v.shape[normalized] {
+		return nil, fmt.Errorf("split sizes %v do not add up to the size %d of axis %d", sizes, v.shape[normalized], axis)
+	}
+
+	// Slice the axis into consecutive views
+	views := make([]*ViewStruct, len(sizes))
+	start := 0
+	for i, size := range sizes {
+		shape := append([]int{}, v.shape...)
+		shape[normalized] = size
+		views[i] = &ViewStruct{shape: shape, stride: v.stride, offset: v.offset + start*v.stride[normalized], tensor: v.tensor}
+		start += size
+	}
+	return views, nil
+}
+
+// Chunk partitions the view along axis into n views of equal size, the last
+// view is smaller when the axis does not divide evenly and fewer views are
+// returned when the axis is shorter than n
+func (v *ViewStruct) Chunk(n int, axis int) ([]*ViewStruct, error) {
+	if n < 1 {
+		return nil, fmt.Errorf("invalid number of chunks: %d", n)
+	}
+	normalized, err := normalizeAxis(axis, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	// Cut the axis into chunks of the rounded up size
+	dim := v.shape[normalized]
+	size := (dim + n - 1) / n
+	sizes := []int{}
+	for start := 0; start < dim; start += size {
+		sizes = append(sizes, min(size, dim-start))
+	}
+	return v.Split(sizes, axis)
+}
+
+// Unbind returns a view for each index of axis with that axis removed, the
+// views share the view's data
+func (v *ViewStruct) Unbind(axis int) ([]*ViewStruct, error) {
+	normalized, err := normalizeAxis(axis, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the axis from the shape and strides
+	shape := append(append([]int{}, v.shape[:normalized]...), v.shape[normalized+1:]...)
+	stride := append(append([]int{}, v.stride[:normalized]...), v.stride[normalized+1:]...)
+
+	// Offset each view to its index along the axis
+	views := make([]*ViewStruct, v.shape[normalized])
+	for i := range views {
+		views[i] = &ViewStruct{shape: shape, stride: stride, offset: v.offset + i*v.stride[normalized], tensor: v.tensor}
+	}
+	return views, nil
+}
+
+// Split partitions the tensor along axis into views with the given sizes,
+// which must add up to the size of the axis
+func (t *TensorStruct) Split(sizes []int, axis int) ([]*ViewStruct, error) {
+	return NewView(t).Split(sizes, axis)
+}
+
+// Chunk partitions the tensor along axis into n views of equal size
+func (t *TensorStruct) Chunk(n int, axis int) ([]*ViewStruct, error) {
+	return NewView(t).Chunk(n, axis)
+}
+
+// Unbind returns a view of the tensor for each index of axis with that axis removed
+func (t *TensorStruct) Unbind(axis int) ([]*ViewStruct, error) {
+	return NewView(t).Unbind(axis)
+}
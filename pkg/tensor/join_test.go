@@ -0,0 +1,96 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestJoin tests concatenating and stacking tensors
+func TestJoin(t *testing.T) {
+	a := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+	b := mustNewTensor(t, []int{2, 2}, []float64{5, 6, 7, 8})
+	row := mustNewTensor(t, []int{2}, []float64{9, 10})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+		expectErr     bool
+	}{
+		{"ConcatRows", func() (*TensorStruct, error) { return Concat(0, a, b) }, []int{4, 2}, []float64{1, 2, 3, 4, 5, 6, 7, 8}, false},
+		{"ConcatColumns", func() (*TensorStruct, error) { return Concat(-1, a, b) }, []int{2, 4}, []float64{1, 2, 5, 6, 3, 4, 7, 8}, false},
+		{"ConcatView", func() (*TensorStruct, error) { return Concat(0, a.Transpose(), b) }, []int{4, 2}, []float64{1, 3, 2, 4, 5, 6, 7, 8}, false},
+		{"ConcatSizeMismatch", func() (*TensorStruct, error) { return Concat(0, a, mustNewTensor(t, []int{1, 3}, []float64{1, 2, 3})) }, nil, nil, true},
+		{"ConcatRankMismatch", func() (*TensorStruct, error) { return Concat(0, a, row) }, nil, nil, true},
+		{"ConcatScalars", func() (*TensorStruct, error) { return Concat(0, NewScalar(1), NewScalar(2)) }, nil, nil, true},
+		{"ConcatEmpty", func() (*TensorStruct, error) { return Concat(0) }, nil, nil, true},
+		{"Stack", func() (*TensorStruct, error) { return Stack(0, a, b) }, []int{2, 2, 2}, []float64{1, 2, 3, 4, 5, 6, 7, 8}, false},
+		{"StackLast", func() (*TensorStruct, error) { return Stack(-1, a, b) }, []int{2, 2, 2}, []float64{1, 5, 2, 6, 3, 7, 4, 8}, false},
+		{"StackScalars", func() (*TensorStruct, error) { return Stack(0, NewScalar(1), NewScalar(2)) }, []int{2}, []float64{1, 2}, false},
+		{"StackMismatch", func() (*TensorStruct, error) { return Stack(0, a, row) }, nil, nil, true},
+		{"HStack", func() (*TensorStruct, error) { return HStack(a, b) }, []int{2, 4}, []float64{1, 2, 5, 6, 3, 4, 7, 8}, false},
+		{"HStackVectors", func() (*TensorStruct, error) { return HStack(row, row) }, []int{4}, []float64{9, 10, 9, 10}, false},
+		{"VStack", func() (*TensorStruct, error) { return VStack(a, row) }, []int{3, 2}, []float64{1, 2, 3, 4, 9, 10}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			checkEqual(t, "Data", tc.expectedData, result.Data())
+		})
+	}
+
+	ints, _ := NewTensorOf([]int{2}, []int32{1, 2})
+	promoted, _ := Concat(0, ints, row)
+	checkEqual(t, "DType", Float64, promoted.DType())
+}
+
+// TestPartition tests splitting a tensor into views
+func TestPartition(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 5}, []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+
+	parts, err := x.Split([]int{1, 3, 1}, 1)
+	if err != nil {
+		t.Fatalf("Split: expected no error, got %v", err)
+	}
+	checkEqual(t, "Split Count", 3, len(parts))
+	checkEqual(t, "Split Shape", []int{2, 3}, parts[1].Shape())
+	checkEqual(t, "Split Data", []float64{1, 2, 3, 6, 7, 8}, parts[1].Data())
+
+	// The parts share the tensor's data
+	parts[2].Set(-1, 1, 0)
+	checkEqual(t, "Shared", -1.0, x.Data()[9])
+
+	if _, err := x.Split([]int{2, 2}, 1); err == nil {
+		t.Error("Split: expected error for sizes that do not cover the axis")
+	}
+
+	chunks, err := x.Chunk(2, -1)
+	if err != nil {
+		t.Fatalf("Chunk: expected no error, got %v", err)
+	}
+	checkEqual(t, "Chunk Count", 2, len(chunks))
+	checkEqual(t, "Chunk Shapes", []int{3, 2}, []int{chunks[0].Shape()[1], chunks[1].Shape()[1]})
+	checkEqual(t, "Chunk Data", []float64{3, 4, 8, -1}, chunks[1].Data())
+
+	many, _ := x.Chunk(4, 0)
+	checkEqual(t, "Short Axis Chunks", 2, len(many))
+	if _, err := x.Chunk(0, 0); err == nil {
+		t.Error("Chunk: expected error for zero chunks")
+	}
+
+	columns, err := x.Transpose().Unbind(0)
+	if err != nil {
+		t.Fatalf("Unbind: expected no error, got %v", err)
+	}
+	checkEqual(t, "Unbind Count", 5, len(columns))
+	checkEqual(t, "Unbind Shape", []int{2}, columns[3].Shape())
+	checkEqual(t, "Unbind Data", []float64{3, 8}, columns[3].Data())
+}
@@ -0,0 +1,377 @@
+package tensor
+
+import (
+	"fmt"
+)
+
+// blockSize is the edge length of the tiles the matrix kernel works on, chosen
+// so a tile of each operand fits in the L1 cache
+const blockSize = 64
+
+// packMatrix copies the rows x cols matrix starting at offset into dst in
+// row-major order
+func packMatrix(dst []float64, data storage, offset int, rows, cols int, rowStride, colStride int) {
+	for i := 0; i < rows; i++ {
+		rowOffset := offset + i*rowStride
+		for j := 0; j < cols; j++ {
+			dst[i*cols+j] = data.get(rowOffset + j*colStride)
+		}
+	}
+}
+
+// gemm accumulates the product of the m x k matrix a and the k x n matrix b
+// into the m x n matrix c, working tile by tile to keep the operands in cache,
+// the bands of blockSize rows are split across the workers
+func gemm(a []float64, b []float64, c []float64, m, k, n int) {
+	bands := (m + blockSize - 1) / blockSize
+	parallelFor(bands, blockSize*k*n, func(start, end int) {
+		gemmRows(a, b, c, start*blockSize, min(end*blockSize, m), k, n)
+	})
+}
+
+// gemmRows accumulates rows [rowStart, rowEnd) of the product of a and b into c
+func gemmRows(a []float64, b []float64, c []float64, rowStart, rowEnd, k, n int) {
+	for i0 := rowStart; i0 < rowEnd; i0 += blockSize {
+		iEnd := min(i0+blockSize, rowEnd)
+		for p0 := 0; p0 < k; p0 += blockSize {
+			pEnd := min(p0+blockSize, k)
+			for j0 := 0; j0 < n; j0 += blockSize {
+				jEnd := min(j0+blockSize, n)
+
+				// Multiply the tiles, streaming along the rows of b and c
+				for i := i0; i < iEnd; i++ {
+					cRow := c[i*n+j0 : i*n+jEnd]
+					for p := p0; p < pEnd; p++ {
+						aip := a[i*k+p]
+						bRow := b[p*n+j0 : p*n+jEnd]
+						for j, bpj := range bRow {
+							cRow[j] += aip * bpj
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// productStorage returns the storage for n elements of a product with the
+// given dtype and the float64 buffer to accumulate the product into, which is
+// the storage itself for Float64 and a scratch buffer for narrower dtypes
+func productStorage(dtype DType, n int) (storage, []float64) {
+	result := newStorage(dtype, n)
+	if data, ok := result.(float64Storage); ok {
+		return result, data
+	}
+	return result, allocFloat64s(n)
+}
+
+// storeProduct converts the accumulated product into result when product is
+// a scratch buffer
+func storeProduct(result storage, product []float64) {
+	if _, ok := result.(float64Storage); ok {
+		return
+	}
+	for i, value := range product {
+		result.set(i, value)
+	}
+}
+
+// matrixProduct multiplies the packed m x k matrix a by the packed k x n
+// matrix b, returning a tensor with the given shape and dtype
+func matrixProduct(a storage, b storage, m, k, n int, shape []int, dtype DType) *TensorStruct {
+	aData, bData := allocFloat64s(m*k), allocFloat64s(k*n)
+	packMatrix(aData, a, 0, m, k, k, 1)
+	packMatrix(bData, b, 0, k, n, n, 1)
+	result, product := productStorage(dtype, m*n)
+	gemm(aData, bData, product, m, k, n)
+	storeProduct(result, product)
+	return newTensor(shape, result)
+}
+
+// permutedStorage returns the elements of x with its axes reordered by axes,
+// packed in row-major order
+func permutedStorage(x Operand, axes []int) storage {
+	shape := make([]int, len(axes))
+	stride := make([]int, len(axes))
+	for i, axis := range axes {
+		shape[i] = x.Shape()[axis]
+		stride[i] = x.Stride()[axis]
+	}
+	return gather(x.storage(), shape, stride, x.Offset())
+}
+
+// matMul returns the matrix product of a and b, a 1D a is treated as a row
+// vector and a 1D b as a column vector with the added axis removed from the
+// result, the leading batch axes of both operands broadcast
+func matMul(a Operand, b Operand) (*TensorStruct, error) {
+	if len(a.Shape()) == 0 || len(b.Shape()) == 0 {
+		return nil, fmt.Errorf("matmul requires operands of at least 1D, got %dD and %dD", len(a.Shape()), len(b.Shape()))
+	}
+
+	// Promote 1D operands to matrices, a vector's added axis has stride 0
+	aShape, aStride := a.Shape(), a.Stride()
+	if len(aShape) == 1 {
+		aShape, aStride = []int{1, aShape[0]}, []int{0, aStride[0]}
+	}
+	bShape, bStride := b.Shape(), b.Stride()
+	if len(bShape) == 1 {
+		bShape, bStride = []int{bShape[0], 1}, []int{bStride[0], 0}
+	}
+
+	// Check the inner dimensions match
+	aRank, bRank := len(aShape), len(bShape)
+	m, k, n := aShape[aRank-2], aShape[aRank-1], bShape[bRank-1]
+	if bShape[bRank-2] != k {
+		return nil, fmt.Errorf("matmul inner dimensions do not match: %v and %v", a.Shape(), b.Shape())
+	}
+
+	// Broadcast the batch axes
+	batchShape, err := broadcastShapes(aShape[:aRank-2], bShape[:bRank-2])
+	if err != nil {
+		return nil, err
+	}
+	aBatchStride := expandStrides(aShape[:aRank-2], aStride[:aRank-2], batchShape)
+	bBatchStride := expandStrides(bShape[:bRank-2], bStride[:bRank-2], batchShape)
+
+	// Find the offsets of the matrices of every batch
+	batches := shapeSize(batchShape)
+	aOffsets, bOffsets := make([]int, batches), make([]int, batches)
+	batch := make([]int, len(batchShape))
+	for i := range batches {
+		aOffsets[i] = offsetAt(a, aBatchStride, batch)
+		bOffsets[i] = offsetAt(b, bBatchStride, batch)
+		nextIndex(batch, batchShape)
+	}
+
+	// Pack each distinct right operand once, the batch axes may broadcast one
+	// matrix to many batches
+	packed := map[int]int{}
+	distinct, bIndex := []int{}, make([]int, batches)
+	for i, offset := range bOffsets {
+		if _, ok := packed[offset]; !ok {
+			packed[offset] = len(distinct)
+			distinct = append(distinct, offset)
+		}
+		bIndex[i] = packed[offset]
+	}
+	bData := allocFloat64s(len(distinct) * k * n)
+	parallelFor(len(distinct), k*n, func(start, end int) {
+		for i := start; i < end; i++ {
+			packMatrix(bData[i*k*n:(i+1)*k*n], b.storage(), distinct[i], k, n, bStride[bRank-2], bStride[bRank-1])
+		}
+	})
+
+	// Multiply the tiles of blockSize rows of every batch across the workers,
+	// each tile packs its own rows of the left operand
+	bands := (m + blockSize - 1) / blockSize
+	result, product := productStorage(arithmeticType(a.DType(), b.DType()), batches*m*n)
+	parallelFor(batches*bands, blockSize*k*n, func(start, end int) {
+		aData := make([]float64, blockSize*k)
+		for tile := start; tile < end; tile++ {
+			i, rowStart := tile/bands, (tile%bands)*blockSize
+			rows := min(blockSize, m-rowStart)
+			packMatrix(aData, a.storage(), aOffsets[i]+rowStart*aStride[aRank-2], rows, k, aStride[aRank-2], aStride[aRank-1])
+			bMatrix := bData[bIndex[i]*k*n:]
+			gemmRows(aData, bMatrix, product[(i*m+rowStart)*n:], 0, rows, k, n)
+		}
+	})
+
+	storeProduct(result, product)
+
+	// Drop the axes added to 1D operands
+	shape := append([]int{}, batchShape...)
+	if len(a.Shape()) > 1 {
+		shape = append(shape, m)
+	}
+	if len(b.Shape()) > 1 {
+		shape = append(shape, n)
+	}
+	return newTensor(shape, result), nil
+}
+
+// dot returns the dot product of a and b, a scalar operand multiplies the
+// other elementwise, a b of at most 2D is a matrix product and otherwise the
+// last axis of a is summed against the second to last axis of b
+func dot(a Operand, b Operand) (*TensorStruct, error) {
+	aShape, bShape := a.Shape(), b.Shape()
+	if len(aShape) == 0 || len(bShape) == 0 {
+		return mul(a, b)
+	}
+	if len(bShape) <= 2 {
+		return matMul(a, b)
+	}
+
+	// Check the summed axes match
+	bRank := len(bShape)
+	k := aShape[len(aShape)-1]
+	if bShape[bRank-2] != k {
+		return nil, fmt.Errorf("dot summed dimensions do not match: %v and %v", aShape, bShape)
+	}
+
+	// Move the summed axis of b to the front, so b packs as a k x n matrix
+	axes := []int{bRank - 2}
+	for i := 0; i < bRank; i++ {
+		if i != bRank-2 {
+			axes = append(axes, i)
+		}
+	}
+	shape := append(append([]int{}, aShape[:len(aShape)-1]...), bShape[:bRank-2]...)
+	shape = append(shape, bShape[bRank-1])
+	m := shapeSize(aShape[:len(aShape)-1])
+	n := shapeSize(bShape[:bRank-2]) * bShape[bRank-1]
+	return matrixProduct(contiguousStorage(a), permutedStorage(b, axes), m, k, n, shape, arithmeticType(a.DType(), b.DType())), nil
+}
+
+// inner returns the inner product of a and b, summing over the last axis of
+// both, a scalar operand multiplies the other elementwise
+func inner(a Operand, b Operand) (*TensorStruct, error) {
+	aShape, bShape := a.Shape(), b.Shape()
+	if len(aShape) == 0 || len(bShape) == 0 {
+		return mul(a, b)
+	}
+
+	// Check the summed axes match
+	aRank, bRank := len(aShape), len(bShape)
+	k := aShape[aRank-1]
+	if bShape[bRank-1] != k {
+		return nil, fmt.Errorf("inner summed dimensions do not match: %v and %v", aShape, bShape)
+	}
+
+	// Move the last axis of b to the front, so b packs as a k x n matrix
+	axes := []int{bRank - 1}
+	for i := 0; i < bRank-1; i++ {
+		axes = append(axes, i)
+	}
+	m, n := shapeSize(aShape[:aRank-1]), shapeSize(bShape[:bRank-1])
+	shape := append(append([]int{}, aShape[:aRank-1]...), bShape[:bRank-1]...)
+	return matrixProduct(contiguousStorage(a), permutedStorage(b, axes), m, k, n, shape, arithmeticType(a.DType(), b.DType())), nil
+}
+
+// outer returns the outer product of a and b, both operands are flattened
+func outer(a Operand, b Operand) *TensorStruct {
+	m, n := shapeSize(a.Shape()), shapeSize(b.Shape())
+	column := newTensor([]int{m, 1}, contiguousStorage(a))
+	row := newTensor([]int{n}, contiguousStorage(b))
+
+	// A column of m rows always broadcasts against a row of n columns
+	result, _ := mul(column, row)
+	return result
+}
+
+// matVec returns the product of the matrix m and the vector v, the leading
+// batch axes of m are kept
+func matVec(m Operand, v Operand) (*TensorStruct, error) {
+	if len(m.Shape()) < 2 {
+		return nil, fmt.Errorf("matvec requires a matrix of at least 2D, got %dD", len(m.Shape()))
+	}
+	if len(v.Shape()) != 1 {
+		return nil, fmt.Errorf("matvec requires a 1D vector, got %dD", len(v.Shape()))
+	}
+	return matMul(m, v)
+}
+
+// kron returns the Kronecker product of a and b, the operand of lower rank is
+// padded with leading axes of size 1
+func kron(a Operand, b Operand) *TensorStruct {
+	// Pad both shapes to the same rank
+	rank := max(len(a.Shape()), len(b.Shape()))
+	aShape, aStride := padShape(a.Shape(), a.Stride(), rank)
+	bShape, bStride := padShape(b.Shape(), b.Stride(), rank)
+
+	// Compute the shape of the result
+	shape := make([]int, rank)
+	for i := range shape {
+		shape[i] = aShape[i] * bShape[i]
+	}
+
+	// Every result index splits into an index of a and an index of b
+	result := newStorage(arithmeticType(a.DType(), b.DType()), shapeSize(shape))
+	aData, bData := a.storage(), b.storage()
+	idx := make([]int, rank)
+	for i := 0; i < result.len(); i++ {
+		aOffset, bOffset := a.Offset(), b.Offset()
+		for j, v := range idx {
+			aOffset += v / bShape[j] * aStride[j]
+			bOffset += v % bShape[j] * bStride[j]
+		}
+		result.set(i, aData.get(aOffset)*bData.get(bOffset))
+		nextIndex(idx, shape)
+	}
+	return newTensor(shape, result)
+}
+
+// padShape prepends axes of size 1 to shape until it has the given rank
+func padShape(shape []int, stride []int, rank int) ([]int, []int) {
+	padding := rank - len(shape)
+	paddedShape := make([]int, rank)
+	paddedStride := make([]int, rank)
+	for i := range paddedShape {
+		if i < padding {
+			paddedShape[i] = 1
+			continue
+		}
+		paddedShape[i] = shape[i-padding]
+		paddedStride[i] = stride[i-padding]
+	}
+	return paddedShape, paddedStride
+}
+
+// MatMul returns the matrix product of the tensor and other
+func (t *TensorStruct) MatMul(other Operand) (*TensorStruct, error) {
+	return matMul(t, other)
+}
+
+// Dot returns the dot product of the tensor and other
+func (t *TensorStruct) Dot(other Operand) (*TensorStruct, error) {
+	return dot(t, other)
+}
+
+// Inner returns the inner product of the tensor and other over their last axes
+func (t *TensorStruct) Inner(other Operand) (*TensorStruct, error) {
+	return inner(t, other)
+}
+
+// Outer returns the outer product of the flattened tensor and other
+func (t *TensorStruct) Outer(other Operand) *TensorStruct {
+	return outer(t, other)
+}
+
+// MatVec returns the product of the tensor as a matrix and the vector other
+func (t *TensorStruct) MatVec(other Operand) (*TensorStruct, error) {
+	return matVec(t, other)
+}
+
+// Kron returns the Kronecker product of the tensor and other
+func (t *TensorStruct) Kron(other Operand) *TensorStruct {
+	return kron(t, other)
+}
+
+// MatMul returns the matrix product of the view and other
+func (v *ViewStruct) MatMul(other Operand) (*TensorStruct, error) {
+	return matMul(v, other)
+}
+
+// Dot returns the dot product of the view and other
+func (v *ViewStruct) Dot(other Operand) (*TensorStruct, error) {
+	return dot(v, other)
+}
+
+// Inner returns the inner product of the view and other over their last axes
+func (v *ViewStruct) Inner(other Operand) (*TensorStruct, error) {
+	return inner(v, other)
+}
+
+// Outer returns the outer product of the flattened view and other
+func (v *ViewStruct) Outer(other Operand) *TensorStruct {
+	return outer(v, other)
+}
+
+// MatVec returns the product of the view as a matrix and the vector other
+func (v *ViewStruct) MatVec(other Operand) (*TensorStruct, error) {
+	return matVec(v, other)
+}
+
+// Kron returns the Kronecker product of the view and other
+func (v *ViewStruct) Kron(other Operand) *TensorStruct {
+	return kron(v, other)
+}
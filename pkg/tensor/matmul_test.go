@@ -0,0 +1,154 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestMatMul tests matrix products with 1D promotion and batch broadcasting
+func TestMatMul(t *testing.T) {
+	a := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	b := mustNewTensor(t, []int{3, 2}, []float64{7, 8, 9, 10, 11, 12})
+	v := mustNewTensor(t, []int{3}, []float64{1, 0, -1})
+	batch := mustNewTensor(t, []int{2, 2, 3}, []float64{1, 2, 3, 4, 5, 6, 1, 0, 0, 0, 1, 0})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+		expectErr     bool
+	}{
+		{"MatrixMatrix", func() (*TensorStruct, error) { return a.MatMul(b) }, []int{2, 2}, []float64{58, 64, 139, 154}, false},
+		{"MatrixVector", func() (*TensorStruct, error) { return a.MatMul(v) }, []int{2}, []float64{-2, -2}, false},
+		{"VectorMatrix", func() (*TensorStruct, error) { return v.MatMul(b) }, []int{2}, []float64{-4, -4}, false},
+		{"VectorVector", func() (*TensorStruct, error) { return v.MatMul(v) }, []int{}, []float64{2}, false},
+		{"BatchMatrix", func() (*TensorStruct, error) { return batch.MatMul(b) }, []int{2, 2, 2}, []float64{58, 64, 139, 154, 7, 8, 9, 10}, false},
+		{"TransposedView", func() (*TensorStruct, error) { return b.Transpose().MatMul(a.Transpose()) }, []int{2, 2}, []float64{58, 139, 64, 154}, false},
+		{"InnerMismatch", func() (*TensorStruct, error) { return a.MatMul(a) }, nil, nil, true},
+		{"Scalar", func() (*TensorStruct, error) { return a.MatMul(NewScalar(2)) }, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			checkEqual(t, "Data", tc.expectedData, result.Data())
+		})
+	}
+}
+
+// TestMatMulBroadcast tests broadcasting the batch axes of both operands
+func TestMatMulBroadcast(t *testing.T) {
+	a := mustNewTensor(t, []int{2, 1, 1, 2}, []float64{1, 2, 3, 4})
+	b := mustNewTensor(t, []int{3, 2, 1}, []float64{1, 0, 0, 1, 1, 1})
+
+	result, err := a.MatMul(b)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Shape", []int{2, 3, 1, 1}, result.Shape())
+	checkEqual(t, "Data", []float64{1, 2, 3, 3, 4, 7}, result.Data())
+
+	ints, _ := NewTensorOf([]int{2, 2}, []int32{1, 2, 3, 4})
+	product, _ := ints.MatMul(ints)
+	checkEqual(t, "DType", Int32, product.DType())
+	checkEqual(t, "Data", []float64{7, 10, 15, 22}, product.Data())
+}
+
+// TestMatMulBlocked tests the blocked kernel against a direct product on
+// matrices that do not divide evenly into blocks
+func TestMatMulBlocked(t *testing.T) {
+	rng := NewRNG(7)
+	m, k, n := 70, 130, 65
+	a, _ := rng.Rand([]int{m, k})
+	b, _ := rng.Rand([]int{k, n})
+
+	result, err := a.MatMul(b)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	aData, bData := a.Data(), b.Data()
+	expected := make([]float64, m*n)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			for p := 0; p < k; p++ {
+				expected[i*n+j] += aData[i*k+p] * bData[p*n+j]
+			}
+		}
+	}
+	if !almostEqual(expected, result.Data()) {
+		t.Error("Blocked product does not match the direct product")
+	}
+}
+
+// TestMatMulAllocations tests that a Float64 product is accumulated directly
+// into its result, drawing only the result and the packed right operand from
+// the pool
+func TestMatMulAllocations(t *testing.T) {
+	defer SetPool(nil)
+	a, _ := Ones([]int{64, 64})
+	b, _ := Ones([]int{64, 64})
+	p := NewPool()
+	SetPool(p)
+
+	product, err := a.MatMul(b)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Misses", int64(2), p.Stats().Misses)
+	checkEqual(t, "LiveBytes", int64(2*64*64*8), p.Stats().LiveBytes)
+	checkEqual(t, "Product", 64.0, product.Data()[0])
+
+	// Narrower dtypes accumulate into a scratch buffer and convert
+	ints, _ := NewTensorOf([]int{2, 2}, []int32{1, 2, 3, 4})
+	intProduct, _ := ints.MatMul(ints)
+	checkEqual(t, "Int32", []float64{7, 10, 15, 22}, intProduct.Data())
+}
+
+// TestProducts tests the dot, inner, outer, matrix vector and Kronecker products
+func TestProducts(t *testing.T) {
+	a := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+	v := mustNewTensor(t, []int{2}, []float64{1, -1})
+	stack := mustNewTensor(t, []int{2, 2, 2}, []float64{1, 0, 0, 1, 2, 0, 0, 2})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+		expectErr     bool
+	}{
+		{"DotVectors", func() (*TensorStruct, error) { return v.Dot(v) }, []int{}, []float64{2}, false},
+		{"DotScalar", func() (*TensorStruct, error) { return a.Dot(NewScalar(2)) }, []int{2, 2}, []float64{2, 4, 6, 8}, false},
+		{"DotStack", func() (*TensorStruct, error) { return a.Dot(stack) }, []int{2, 2, 2}, []float64{1, 2, 2, 4, 3, 4, 6, 8}, false},
+		{"DotMismatch", func() (*TensorStruct, error) { return stack.Dot(mustNewTensor(t, []int{3}, []float64{1, 2, 3})) }, nil, nil, true},
+		{"Inner", func() (*TensorStruct, error) { return a.Inner(a) }, []int{2, 2}, []float64{5, 11, 11, 25}, false},
+		{"InnerVector", func() (*TensorStruct, error) { return a.Inner(v) }, []int{2}, []float64{-1, -1}, false},
+		{"InnerMismatch", func() (*TensorStruct, error) { return a.Inner(mustNewTensor(t, []int{3}, []float64{1, 2, 3})) }, nil, nil, true},
+		{"Outer", func() (*TensorStruct, error) { return v.Outer(a), nil }, []int{2, 4}, []float64{1, 2, 3, 4, -1, -2, -3, -4}, false},
+		{"MatVec", func() (*TensorStruct, error) { return a.MatVec(v) }, []int{2}, []float64{-1, -1}, false},
+		{"MatVecMatrix", func() (*TensorStruct, error) { return a.MatVec(a) }, nil, nil, true},
+		{"Kron", func() (*TensorStruct, error) { return v.Kron(a), nil }, []int{2, 4}, []float64{1, 2, -1, -2, 3, 4, -3, -4}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			checkEqual(t, "Data", tc.expectedData, result.Data())
+		})
+	}
+}
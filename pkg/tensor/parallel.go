@@ -0,0 +1,104 @@
+package tensor
+
+import (
+	"runtime"
+	"sync"
+)
+
+// parallelThreshold is the amount of work, counted in element operations,
+// below which kernels run serially on the calling goroutine
+const parallelThreshold = 1 << 15
+
+// workerPool runs the chunks of parallel kernels on a fixed set of goroutines,
+// the goroutine calling a kernel always runs one chunk itself
+type workerPool struct {
+	mu    sync.RWMutex
+	size  int
+	tasks chan func()
+}
+
+// workers is the package-level pool shared by every kernel
+var workers = newWorkerPool(runtime.GOMAXPROCS(0))
+
+// newWorkerPool starts a pool running kernels on size goroutines
+func newWorkerPool(size int) *workerPool {
+	w := &workerPool{}
+	w.start(size)
+	return w
+}
+
+// start launches the goroutines of the pool, one fewer than size as the
+// calling goroutine takes part in every kernel
+func (w *workerPool) start(size int) {
+	w.size = size
+	w.tasks = make(chan func())
+	for i := 1; i < size; i++ {
+		go func(tasks chan func()) {
+			for task := range tasks {
+				task()
+			}
+		}(w.tasks)
+	}
+}
+
+// SetNumThreads sets the number of goroutines kernels are split across, a
+// value below 1 restores the default of runtime.GOMAXPROCS
+func SetNumThreads(n int) {
+	if n < 1 {
+		n = runtime.GOMAXPROCS(0)
+	}
+	workers.mu.Lock()
+	defer workers.mu.Unlock()
+	close(workers.tasks)
+	workers.start(n)
+}
+
+// NumThreads returns the number of goroutines kernels are split across
+func NumThreads() int {
+	workers.mu.RLock()
+	defer workers.mu.RUnlock()
+	return workers.size
+}
+
+// parallelFor calls fn over contiguous chunks of [0, n) that together cover
+// the range exactly once, cost is the work per item and ranges with less than
+// parallelThreshold work in total run serially, fn must only write to the
+// items of its own chunk
+func parallelFor(n int, cost int, fn func(start, end int)) {
+	// Split the range into at most one chunk per thread
+	workers.mu.RLock()
+	chunks := min(workers.size, n, max(1, n*cost/parallelThreshold))
+	if chunks <= 1 {
+		workers.mu.RUnlock()
+		if n > 0 {
+			fn(0, n)
+		}
+		return
+	}
+	size := (n + chunks - 1) / chunks
+
+	// Hand the chunks to idle workers, keeping those no worker is free for
+	var wg sync.WaitGroup
+	local := []func(){}
+	for start := size; start < n; start += size {
+		end := min(start+size, n)
+		wg.Add(1)
+		task := func() {
+			defer wg.Done()
+			fn(start, end)
+		}
+		select {
+		case workers.tasks <- task:
+		default:
+			local = append(local, task)
+		}
+	}
+	workers.mu.RUnlock()
+
+	// Run the first chunk and the chunks no worker took, then wait for the rest
+	fn(0, min(size, n))
+	for _, task := range local {
+		task()
+	}
+	wg.Wait()
+}
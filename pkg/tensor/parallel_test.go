@@ -0,0 +1,128 @@
+package tensor
+
+import (
+	"runtime"
+	"testing"
+)
+
+// TestNumThreads tests setting and resetting the number of threads
+func TestNumThreads(t *testing.T) {
+	defer SetNumThreads(0)
+
+	SetNumThreads(3)
+	checkEqual(t, "NumThreads", 3, NumThreads())
+
+	SetNumThreads(0)
+	checkEqual(t, "Default NumThreads", runtime.GOMAXPROCS(0), NumThreads())
+}
+
+// TestParallelFor tests that every item is visited exactly once
+func TestParallelFor(t *testing.T) {
+	defer SetNumThreads(0)
+	SetNumThreads(4)
+
+	for _, n := range []int{0, 1, 7, parallelThreshold + 3} {
+		visits := make([]int, n)
+		parallelFor(n, 1, func(start, end int) {
+			for i := start; i < end; i++ {
+				visits[i]++
+			}
+		})
+		for i, v := range visits {
+			if v != 1 {
+				t.Fatalf("n=%d: item %d visited %d times", n, i, v)
+			}
+		}
+	}
+}
+
+// TestParallelFullReduction tests that reducing every axis of a tensor above
+// the parallel threshold gives bit-identical results for any number of threads
+func TestParallelFullReduction(t *testing.T) {
+	defer SetNumThreads(0)
+
+	rng := NewRNG(5)
+	x, _ := rng.Randn([]int{8 * parallelThreshold})
+	matrix, _ := NewView(x).Reshape([]int{512, 512})
+
+	run := func() []float64 {
+		sum, _ := x.Sum(nil, false)
+		mean, _ := x.Mean(nil, false)
+		norm, _ := x.Norm(2, nil, false)
+		largest, _ := x.Max(nil, false)
+		strided, _ := matrix.Transpose().Sum(nil, false)
+		columns, _ := matrix.Sum([]int{0}, false)
+		return append([]float64{sum.Data()[0], mean.Data()[0], norm.Data()[0], largest.Data()[0], strided.Data()[0]}, columns.Data()...)
+	}
+
+	SetNumThreads(1)
+	serial := run()
+	for _, threads := range []int{2, 3, 8} {
+		SetNumThreads(threads)
+		checkEqual(t, "Results", serial, run())
+	}
+}
+
+// TestParallelBatchedMatMul tests that batched products of matrices with
+// fewer than blockSize rows are split across the workers by batch and give
+// the products of the single matrices
+func TestParallelBatchedMatMul(t *testing.T) {
+	defer SetNumThreads(0)
+
+	rng := NewRNG(3)
+	q, _ := rng.Randn([]int{16, 32, 64})
+	k, _ := rng.Randn([]int{16, 32, 64})
+	keys, _ := k.SwapAxes(1, 2)
+
+	SetNumThreads(1)
+	serial, err := q.MatMul(keys)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	SetNumThreads(4)
+	scores, _ := q.MatMul(keys)
+	checkEqual(t, "Scores", serial.Data(), scores.Data())
+
+	// Every batch matches the product of its matrices
+	qs, _ := q.Unbind(0)
+	ks, _ := keys.Unbind(0)
+	rows, _ := scores.Unbind(0)
+	for i := range qs {
+		expected, _ := qs[i].MatMul(ks[i])
+		checkEqual(t, "Batch", expected.Data(), rows[i].Data())
+	}
+}
+
+// TestParallelDeterminism tests that kernels give the same result for any
+// number of threads
+func TestParallelDeterminism(t *testing.T) {
+	defer SetNumThreads(0)
+
+	rng := NewRNG(11)
+	x, _ := rng.Randn([]int{256, 300})
+	y, _ := rng.Randn([]int{300, 128})
+	view := x.Transpose()
+
+	run := func() [][]float64 {
+		sum, _ := x.Sum([]int{1}, false)
+		total, _ := view.Sum(nil, false)
+		std, _ := view.Std([]int{0}, 1, false)
+		product, _ := x.MatMul(y)
+		scaled, _ := view.Mul(NewScalar(2))
+		return [][]float64{sum.Data(), total.Data(), std.Data(), product.Data(), scaled.Data(), view.Exp().Data()}
+	}
+
+	SetNumThreads(1)
+	serial := run()
+	for _, threads := range []int{2, 5} {
+		SetNumThreads(threads)
+		checkEqual(t, "Results", serial, run())
+	}
+
+	// An error in any chunk is reported
+	SetNumThreads(4)
+	zeros, _ := Zeros([]int{256, 300})
+	if _, err := x.Div(zeros); err == nil {
+		t.Error("Expected division by zero error")
+	}
+}
@@ -0,0 +1,107 @@
+package tensor
+
+import (
+	"fmt"
+)
+
+// Permute returns a view with the axes reordered, axis i of the result is
+// axis axes[i] of the view, no data is copied
+func (v *ViewStruct) Permute(axes ...int) (*ViewStruct, error) {
+	// Check that there is one axis per dimension
+	rank := len(v.shape)
+	if len(axes) != rank {
+		return nil, fmt.Errorf("permutation needs %d axes, got %d", rank, len(axes))
+	}
+
+	// Reorder the shape and strides, checking each axis is used once
+	shape := make([]int, rank)
+	stride := make([]int, rank)
+	used := make([]bool, rank)
+	for i, axis := range axes {
+		axis, err := normalizeAxis(axis, rank)
+		if err != nil {
+			return nil, err
+		}
+		if used[axis] {
+			return nil, fmt.Errorf("axis %d repeated in permutation %v", axis, axes)
+		}
+		used[axis] = true
+		shape[i] = v.shape[axis]
+		stride[i] = v.stride[axis]
+	}
+
+	// Return the view
+	return &ViewStruct{
+		shape:  shape,
+		stride: stride,
+		offset: v.offset,
+		tensor: v.tensor,
+	}, nil
+}
+
+// Transpose returns a view with the order of the axes reversed
+func (v *ViewStruct) Transpose() *ViewStruct {
+	axes := make([]int, len(v.shape))
+	for i := range axes {
+		axes[i] = len(axes) - 1 - i
+	}
+	transposed, _ := v.Permute(axes...)
+	return transposed
+}
+
+// SwapAxes returns a view with the two axes exchanged
+func (v *ViewStruct) SwapAxes(a, b int) (*ViewStruct, error) {
+	// Check the axes
+	a, err := normalizeAxis(a, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+	b, err = normalizeAxis(b, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	// Swap the axes
+	axes := make([]int, len(v.shape))
+	for i := range axes {
+		axes[i] = i
+	}
+	axes[a], axes[b] = axes[b], axes[a]
+	return v.Permute(axes...)
+}
+
+// MoveAxis returns a view with the axis at src moved to position dst, the
+// other axes keep their relative order
+func (v *ViewStruct) MoveAxis(src, dst int) (*ViewStruct, error) {
+	// Check the axes
+	src, err := normalizeAxis(src, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+	dst, err = normalizeAxis(dst, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	// Remove the axis from the order and insert it at its destination
+	axes := make([]int, 0, len(v.shape))
+	for i := range v.shape {
+		if i != src {
+			axes = append(axes, i)
+		}
+	}
+	axes = append(axes[:dst], append([]int{src}, axes[dst:]...)...)
+	return v.Permute(axes...)
+}
+
+// IsContiguous reports whether the view's elements are packed in row-major
+// order in the tensor's data, the strides of size 1 axes are ignored
+func (v *ViewStruct) IsContiguous() bool {
+	return isPacked(v.shape, v.stride)
+}
+
+// Contiguous returns a packed tensor holding the view's elements, a
+// contiguous view shares its data with the tensor, any other view is copied
+func (v *ViewStruct) Contiguous() *TensorStruct {
+	return newTensor(v.shape, contiguousStorage(v))
+}
@@ -0,0 +1,114 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestPermute tests reordering axes as views
+func TestPermute(t *testing.T) {
+	// 2x3x4 tensor holding 0..23
+	data := make([]float64, 24)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	tensor := mustNewTensor(t, []int{2, 3, 4}, data)
+
+	testCases := []struct {
+		name           string
+		apply          func() (*ViewStruct, error)
+		expectedShape  []int
+		expectedStride []int
+		expectErr      bool
+	}{
+		{"Transpose", func() (*ViewStruct, error) { return tensor.Transpose(), nil }, []int{4, 3, 2}, []int{1, 4, 12}, false},
+		{"Permute", func() (*ViewStruct, error) { return tensor.Permute(1, 2, 0) }, []int{3, 4, 2}, []int{4, 1, 12}, false},
+		{"PermuteNegative", func() (*ViewStruct, error) { return tensor.Permute(-1, 0, 1) }, []int{4, 2, 3}, []int{1, 12, 4}, false},
+		{"PermuteRepeated", func() (*ViewStruct, error) { return tensor.Permute(0, 0, 1) }, nil, nil, true},
+		{"PermuteTooFew", func() (*ViewStruct, error) { return tensor.Permute(0, 1) }, nil, nil, true},
+		{"PermuteOutOfRange", func() (*ViewStruct, error) { return tensor.Permute(0, 1, 3) }, nil, nil, true},
+		{"SwapAxes", func() (*ViewStruct, error) { return tensor.SwapAxes(0, 2) }, []int{4, 3, 2}, []int{1, 4, 12}, false},
+		{"SwapAxesNegative", func() (*ViewStruct, error) { return tensor.SwapAxes(-1, -2) }, []int{2, 4, 3}, []int{12, 1, 4}, false},
+		{"SwapAxesOutOfRange", func() (*ViewStruct, error) { return tensor.SwapAxes(0, 5) }, nil, nil, true},
+		{"MoveAxisForward", func() (*ViewStruct, error) { return tensor.MoveAxis(0, 2) }, []int{3, 4, 2}, []int{4, 1, 12}, false},
+		{"MoveAxisBackward", func() (*ViewStruct, error) { return tensor.MoveAxis(-1, 0) }, []int{4, 2, 3}, []int{1, 12, 4}, false},
+		{"MoveAxisOutOfRange", func() (*ViewStruct, error) { return tensor.MoveAxis(3, 0) }, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			view, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, view.Shape())
+			checkEqual(t, "Stride", tc.expectedStride, view.Stride())
+		})
+	}
+}
+
+// TestTransposeMatrix tests reading a transposed matrix
+func TestTransposeMatrix(t *testing.T) {
+	matrix := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	transposed := matrix.Transpose()
+
+	got, err := transposed.At(2, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "At", 6.0, got)
+	checkEqual(t, "Values", []float64{1, 4, 2, 5, 3, 6}, viewValues(t, transposed))
+
+	// Transposing back restores the original layout
+	restored := transposed.Transpose()
+	checkEqual(t, "Restored Stride", []int{3, 1}, restored.Stride())
+	if !restored.IsContiguous() {
+		t.Error("Expected restored view to be contiguous")
+	}
+}
+
+// TestContiguous tests checking and packing views
+func TestContiguous(t *testing.T) {
+	matrix := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	if !matrix.IsContiguous() {
+		t.Error("Expected tensor to be contiguous")
+	}
+	if matrix.Contiguous() != matrix {
+		t.Error("Expected Contiguous of a tensor to return the tensor")
+	}
+
+	// A transposed view is copied into a packed tensor
+	transposed := matrix.Transpose()
+	if transposed.IsContiguous() {
+		t.Error("Expected transposed view not to be contiguous")
+	}
+	packed := transposed.Contiguous()
+	checkEqual(t, "Packed Shape", []int{3, 2}, packed.Shape())
+	checkEqual(t, "Packed Stride", []int{2, 1}, packed.Stride())
+	checkEqual(t, "Packed Data", []float64{1, 4, 2, 5, 3, 6}, packed.Data())
+
+	// A contiguous row slice shares the tensor's data
+	row, err := matrix.Slice(Index(1))
+	if err != nil {
+		t.Fatalf("Failed to slice: %v", err)
+	}
+	if !row.IsContiguous() {
+		t.Error("Expected row slice to be contiguous")
+	}
+	shared := row.Contiguous()
+	checkEqual(t, "Shared Data", []float64{4, 5, 6}, shared.Data())
+	if err := shared.Set(40, 0); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+	checkEqual(t, "Tensor Data", []float64{1, 2, 3, 40, 5, 6}, matrix.Data())
+
+	// A strided column slice is not contiguous
+	column, _ := matrix.Slice(All(), Index(0))
+	if column.IsContiguous() {
+		t.Error("Expected column slice not to be contiguous")
+	}
+	checkEqual(t, "Column Data", []float64{1, 40}, column.Contiguous().Data())
+}
@@ -0,0 +1,119 @@
+package tensor
+
+import (
+	"math/bits"
+	"sync"
+	"sync/atomic"
+)
+
+// PoolStats describes the buffers handed out by a Pool
+type PoolStats struct {
+	LiveBytes int64
+	PeakBytes int64
+	Hits      int64
+	Misses    int64
+}
+
+// HitRate returns the fraction of requests served by a reused buffer
+func (s PoolStats) HitRate() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+// Pool is an arena of float64 buffers bucketed by power of two capacity,
+// buffers handed out by Get stay in use until Release returns all of them to
+// the pool at once, typically at the end of a training step
+type Pool struct {
+	mu    sync.Mutex
+	free  [][][]float64
+	inUse [][]float64
+	stats PoolStats
+}
+
+// NewPool creates an empty pool
+func NewPool() *Pool {
+	return &Pool{free: make([][][]float64, bits.UintSize+1)}
+}
+
+// bucketOf returns the bucket holding buffers with room for n elements
+func bucketOf(n int) int {
+	return bits.Len(uint(n - 1))
+}
+
+// Get returns a zeroed buffer of length n, reusing a released buffer of the
+// same bucket when there is one
+func (p *Pool) Get(n int) []float64 {
+	if n <= 0 {
+		return []float64{}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// Reuse a released buffer or allocate one with the bucket's capacity
+	bucket := bucketOf(n)
+	var buf []float64
+	if free := p.free[bucket]; len(free) > 0 {
+		buf = free[len(free)-1][:n]
+		p.free[bucket] = free[:len(free)-1]
+		clear(buf)
+		p.stats.Hits++
+	} else {
+		buf = make([]float64, n, 1<<bucket)
+		p.stats.Misses++
+	}
+
+	// Track the buffer until it is released
+	p.inUse = append(p.inUse, buf)
+	p.stats.LiveBytes += int64(cap(buf)) * 8
+	p.stats.PeakBytes = max(p.stats.PeakBytes, p.stats.LiveBytes)
+	return buf
+}
+
+// Release returns every buffer handed out since the last Release to the pool,
+// tensors backed by those buffers must not be used afterwards
+func (p *Pool) Release() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for i, buf := range p.inUse {
+		bucket := bucketOf(cap(buf))
+		p.free[bucket] = append(p.free[bucket], buf)
+		p.inUse[i] = nil
+	}
+	p.inUse = p.inUse[:0]
+	p.stats.LiveBytes = 0
+}
+
+// Stats returns the statistics of the pool
+func (p *Pool) Stats() PoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.stats
+}
+
+// currentPool is the pool new float64 tensors draw their storage from, nil
+// when tensors are allocated on the heap
+var currentPool atomic.Pointer[Pool]
+
+// SetPool sets the pool the storage of float64 tensors created by ops and
+// factories such as Zeros, Arange and NewTensorFill is drawn from, nil
+// allocates on the heap, NewTensor and NewTensorOf wrap the data they are
+// given and never draw from the pool
+func SetPool(p *Pool) {
+	currentPool.Store(p)
+}
+
+// CurrentPool returns the pool set by SetPool, or nil
+func CurrentPool() *Pool {
+	return currentPool.Load()
+}
+
+// allocFloat64s returns a zeroed buffer of length n from the current pool, or
+// from the heap when no pool is set
+func allocFloat64s(n int) []float64 {
+	if p := currentPool.Load(); p != nil {
+		return p.Get(n)
+	}
+	return make([]float64, n)
+}
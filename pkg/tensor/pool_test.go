@@ -0,0 +1,90 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestPool tests handing out and releasing buffers
+func TestPool(t *testing.T) {
+	p := NewPool()
+
+	a := p.Get(5)
+	p.Get(3)
+	checkEqual(t, "Length", 5, len(a))
+	checkEqual(t, "Capacity", 8, cap(a))
+	checkEqual(t, "Stats", PoolStats{LiveBytes: 96, PeakBytes: 96, Misses: 2}, p.Stats())
+
+	// Released buffers are reused zeroed for requests of the same bucket
+	a[0] = 42
+	p.Release()
+	checkEqual(t, "Released Stats", PoolStats{LiveBytes: 0, PeakBytes: 96, Misses: 2}, p.Stats())
+	c := p.Get(7)
+	checkEqual(t, "Reused", []float64{0, 0, 0, 0, 0, 0, 0}, c)
+	checkEqual(t, "Reused Stats", PoolStats{LiveBytes: 64, PeakBytes: 96, Hits: 1, Misses: 2}, p.Stats())
+	checkEqual(t, "HitRate", 1.0/3, p.Stats().HitRate())
+
+	checkEqual(t, "Empty", 0, len(p.Get(0)))
+	checkEqual(t, "Empty HitRate", 0.0, PoolStats{}.HitRate())
+}
+
+// TestCurrentPool tests ops drawing their results from the current pool
+func TestCurrentPool(t *testing.T) {
+	defer SetPool(nil)
+	p := NewPool()
+	SetPool(p)
+	checkEqual(t, "CurrentPool", p, CurrentPool())
+
+	w := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+	x := mustNewTensor(t, []int{2}, []float64{1, 1})
+	step := func() []float64 {
+		wx, _ := w.MatMul(x)
+		out, _ := wx.Add(NewScalar(1))
+		return out.Data()
+	}
+
+	// After the first step every allocation is served by a released buffer
+	checkEqual(t, "First Step", []float64{4, 8}, step())
+	p.Release()
+	misses := p.Stats().Misses
+	checkEqual(t, "Second Step", []float64{4, 8}, step())
+	checkEqual(t, "Misses", misses, p.Stats().Misses)
+	if p.Stats().Hits == 0 {
+		t.Error("Expected the second step to reuse buffers")
+	}
+
+	// Integer results are not pooled
+	ints, _ := NewTensorOf([]int{2}, []int64{1, 2})
+	before := p.Stats()
+	ints.Add(ints)
+	checkEqual(t, "Integer Stats", before, p.Stats())
+}
+
+// TestPoolFactories tests which constructors draw from the current pool
+func TestPoolFactories(t *testing.T) {
+	defer SetPool(nil)
+	p := NewPool()
+	SetPool(p)
+	requests := func() int64 {
+		return p.Stats().Hits + p.Stats().Misses
+	}
+
+	factories := map[string]func() (*TensorStruct, error){
+		"Zeros":         func() (*TensorStruct, error) { return Zeros([]int{2, 3}) },
+		"Arange":        func() (*TensorStruct, error) { return Arange(0, 5, 1) },
+		"Linspace":      func() (*TensorStruct, error) { return Linspace(0, 1, 5) },
+		"Logspace":      func() (*TensorStruct, error) { return Logspace(0, 2, 3, 10) },
+		"NewTensorFill": func() (*TensorStruct, error) { return NewTensorFill([]int{2, 2}, []float64{1, 2}) },
+	}
+	for name, factory := range factories {
+		before := requests()
+		if _, err := factory(); err != nil {
+			t.Fatalf("Expected no error from %s, got %v", name, err)
+		}
+		checkEqual(t, name, before+1, requests())
+	}
+
+	// NewTensor wraps the data it is given
+	before := requests()
+	mustNewTensor(t, []int{2}, []float64{1, 2})
+	checkEqual(t, "NewTensor", before, requests())
+}
@@ -0,0 +1,227 @@
+package tensor
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// RNG is a seedable random number generator producing tensors, the same seed
+// always produces the same sequence of tensors
+type RNG struct {
+	source *rand.Rand
+}
+
+// NewRNG creates a new random number generator from a seed
+func NewRNG(seed uint64) *RNG {
+	return &RNG{
+		source: rand.New(rand.NewPCG(seed, seed^0x9e3779b97f4a7c15)),
+	}
+}
+
+// Split returns a new generator with a stream independent of this one, the
+// new generator is seeded from this one so splitting is also reproducible
+func (r *RNG) Split() *RNG {
+	return &RNG{
+		source: rand.New(rand.NewPCG(r.source.Uint64(), r.source.Uint64())),
+	}
+}
+
+// fill creates a tensor of the given shape and dtype with values from next
+func (r *RNG) fill(shape []int, dtype DType, next func() float64) (*TensorStruct, error) {
+	// Check the shape
+	if err := validShape(shape); err != nil {
+		return nil, err
+	}
+
+	// Fill the data
+	data := newStorage(dtype, shapeSize(shape))
+	for i := 0; i < data.len(); i++ {
+		data.set(i, next())
+	}
+
+	// Return the tensor
+	return newTensor(shape, data), nil
+}
+
+// Rand creates a float64 tensor of values drawn uniformly from [0, 1)
+func (r *RNG) Rand(shape []int) (*TensorStruct, error) {
+	return r.fill(shape, Float64, r.source.Float64)
+}
+
+// Uniform creates a float64 tensor of values drawn uniformly from [low, high)
+func (r *RNG) Uniform(shape []int, low, high float64) (*TensorStruct, error) {
+	// Check the bounds
+	if !(low < high) {
+		return nil, fmt.Errorf("invalid uniform bounds: low %v must be less than high %v", low, high)
+	}
+
+	// Fill the data
+	return r.fill(shape, Float64, func() float64 {
+		return low + (high-low)*r.source.Float64()
+	})
+}
+
+// Randn creates a float64 tensor of values drawn from the standard normal distribution
+func (r *RNG) Randn(shape []int) (*TensorStruct, error) {
+	return r.fill(shape, Float64, r.source.NormFloat64)
+}
+
+// Normal creates a float64 tensor of values drawn from a normal distribution
+// with the given mean and standard deviation
+func (r *RNG) Normal(shape []int, mean, std float64) (*TensorStruct, error) {
+	// Check the standard deviation
+	if std < 0 {
+		return nil, fmt.Errorf("invalid standard deviation: %v", std)
+	}
+
+	// Fill the data
+	return r.fill(shape, Float64, func() float64 {
+		return mean + std*r.source.NormFloat64()
+	})
+}
+
+// RandInt creates an int64 tensor of integers drawn uniformly from [low, high)
+func (r *RNG) RandInt(shape []int, low, high int64) (*TensorStruct, error) {
+	// Check the bounds
+	if low >= high {
+		return nil, fmt.Errorf("invalid integer bounds: low %d must be less than high %d", low, high)
+	}
+
+	// Check the shape
+	if err := validShape(shape); err != nil {
+		return nil, err
+	}
+
+	// Fill the data, drawing offsets from low as uint64 so the span of any
+	// bounds fits and every value stays exact
+	data := newStorage(Int64, shapeSize(shape))
+	span := uint64(high) - uint64(low)
+	for i := 0; i < data.len(); i++ {
+		data.setInt(i, int64(uint64(low)+r.source.Uint64N(span)))
+	}
+
+	// Return the tensor
+	return newTensor(shape, data), nil
+}
+
+// Bernoulli creates a float64 tensor of ones with probability p and zeros otherwise
+func (r *RNG) Bernoulli(shape []int, p float64) (*TensorStruct, error) {
+	// Check the probability
+	if p < 0 || p > 1 {
+		return nil, fmt.Errorf("invalid probability: %v", p)
+	}
+
+	// Fill the data
+	return r.fill(shape, Float64, func() float64 {
+		if r.source.Float64() < p {
+			return 1
+		}
+		return 0
+	})
+}
+
+// Multinomial draws n category indices from the unnormalized weights in the
+// 1D tensor probs, returning them as an int64 tensor of shape [n], without
+// replacement each category can be drawn at most once
+func (r *RNG) Multinomial(probs *TensorStruct, n int, replacement bool) (*TensorStruct, error) {
+	// Check the weights
+	if probs.Rank() != 1 {
+		return nil, fmt.Errorf("multinomial requires 1D weights, got %dD", probs.Rank())
+	}
+	weights := probs.Data()
+	categories := 0
+	for i, w := range weights {
+		if w < 0 {
+			return nil, fmt.Errorf("negative weight %v at index %d", w, i)
+		}
+		if w > 0 {
+			categories++
+		}
+	}
+
+	// Check the number of draws
+	if n < 0 {
+		return nil, fmt.Errorf("invalid number of draws: %d", n)
+	}
+	if categories == 0 && n > 0 {
+		return nil, fmt.Errorf("weights must have a positive sum")
+	}
+	if !replacement && n > categories {
+		return nil, fmt.Errorf("cannot draw %d categories without replacement from %d", n, categories)
+	}
+
+	// Copy the weights so drawn categories can be removed
+	remaining := make([]float64, len(weights))
+	copy(remaining, weights)
+
+	// Draw the categories
+	result := make(int64Storage, n)
+	for i := range result {
+		// Sum the remaining weights
+		total := 0.0
+		for _, w := range remaining {
+			total += w
+		}
+
+		// Find the category the draw falls in, skipping empty categories
+		target := r.source.Float64() * total
+		category := -1
+		for j, w := range remaining {
+			if w == 0 {
+				continue
+			}
+			category = j
+			if target < w {
+				break
+			}
+			target -= w
+		}
+		result[i] = int64(category)
+
+		// Remove the category when drawing without replacement
+		if !replacement {
+			remaining[category] = 0
+		}
+	}
+
+	// Return the draws
+	return newTensor([]int{n}, result), nil
+}
+
+// Permutation creates an int64 tensor holding a random permutation of [0, n)
+func (r *RNG) Permutation(n int) (*TensorStruct, error) {
+	// Check the length
+	if n < 0 {
+		return nil, fmt.Errorf("invalid permutation length: %d", n)
+	}
+
+	// Shuffle the indices
+	result := make(int64Storage, n)
+	for i, v := range r.source.Perm(n) {
+		result[i] = int64(v)
+	}
+
+	// Return the permutation
+	return newTensor([]int{n}, result), nil
+}
+
+// Shuffle returns a copy of t with its entries along the first axis in random order
+func (r *RNG) Shuffle(t *TensorStruct) (*TensorStruct, error) {
+	// Check that there is an axis to shuffle
+	if t.Rank() == 0 {
+		return nil, fmt.Errorf("cannot shuffle a scalar")
+	}
+
+	// Copy each entry of the first axis to its new position
+	rows := t.shape[0]
+	rowSize := t.stride[0]
+	data := newStorage(t.DType(), t.data.len())
+	for dst, src := range r.source.Perm(rows) {
+		for j := 0; j < rowSize; j++ {
+			copyElement(data, dst*rowSize+j, t.data, src*rowSize+j)
+		}
+	}
+
+	// Return the shuffled tensor
+	return newTensor(t.shape, data), nil
+}
@@ -0,0 +1,178 @@
+package tensor
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// TestRNGReproducible tests that the same seed produces identical tensors
+func TestRNGReproducible(t *testing.T) {
+	testCases := []struct {
+		name   string
+		create func(r *RNG) (*TensorStruct, error)
+	}{
+		{"Rand", func(r *RNG) (*TensorStruct, error) { return r.Rand([]int{2, 3}) }},
+		{"Uniform", func(r *RNG) (*TensorStruct, error) { return r.Uniform([]int{4}, -1, 1) }},
+		{"Randn", func(r *RNG) (*TensorStruct, error) { return r.Randn([]int{3, 2}) }},
+		{"Normal", func(r *RNG) (*TensorStruct, error) { return r.Normal([]int{5}, 2, 0.5) }},
+		{"RandInt", func(r *RNG) (*TensorStruct, error) { return r.RandInt([]int{6}, 0, 10) }},
+		{"Bernoulli", func(r *RNG) (*TensorStruct, error) { return r.Bernoulli([]int{8}, 0.3) }},
+		{"Permutation", func(r *RNG) (*TensorStruct, error) { return r.Permutation(7) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			first, err := tc.create(NewRNG(42))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			second, err := tc.create(NewRNG(42))
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "Data", first.Data(), second.Data())
+		})
+	}
+}
+
+// TestRNGRanges tests that generated values fall in their ranges
+func TestRNGRanges(t *testing.T) {
+	r := NewRNG(7)
+
+	uniform, _ := r.Uniform([]int{100}, 2, 3)
+	for _, v := range uniform.Data() {
+		if v < 2 || v >= 3 {
+			t.Fatalf("Uniform value %v outside [2, 3)", v)
+		}
+	}
+
+	ints, _ := r.RandInt([]int{100}, -2, 2)
+	checkEqual(t, "RandInt DType", Int64, ints.DType())
+	for _, v := range ints.Data() {
+		if v < -2 || v >= 2 || v != math.Trunc(v) {
+			t.Fatalf("RandInt value %v outside [-2, 2)", v)
+		}
+	}
+
+	bits, _ := r.Bernoulli([]int{100}, 0.5)
+	for _, v := range bits.Data() {
+		if v != 0 && v != 1 {
+			t.Fatalf("Bernoulli value %v is not 0 or 1", v)
+		}
+	}
+
+	perm, _ := r.Permutation(10)
+	values := perm.Data()
+	sort.Float64s(values)
+	checkEqual(t, "Permutation", []float64{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, values)
+}
+
+// TestRandIntExact tests integers drawn from spans beyond 2^53 and from the
+// full int64 range
+func TestRandIntExact(t *testing.T) {
+	r := NewRNG(7)
+
+	full, err := r.RandInt([]int{3}, math.MinInt64, math.MaxInt64)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Full Shape", []int{3}, full.Shape())
+
+	large, err := r.RandInt([]int{64}, 0, 1<<62)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	values, _ := DataOf[int64](large)
+	odd := false
+	for _, v := range values {
+		if v < 0 || v >= 1<<62 {
+			t.Fatalf("RandInt value %d outside [0, 2^62)", v)
+		}
+		odd = odd || v%2 == 1
+	}
+	if !odd {
+		t.Errorf("Expected odd values above 2^53, got %v", values)
+	}
+}
+
+// TestRNGErrors tests invalid generator arguments
+func TestRNGErrors(t *testing.T) {
+	r := NewRNG(1)
+
+	if _, err := r.Rand([]int{-1}); err == nil {
+		t.Error("Rand: expected error for negative shape")
+	}
+	if _, err := r.Uniform([]int{1}, 1, 1); err == nil {
+		t.Error("Uniform: expected error for empty bounds")
+	}
+	if _, err := r.RandInt([]int{1}, 3, 3); err == nil {
+		t.Error("RandInt: expected error for empty bounds")
+	}
+	if _, err := r.Bernoulli([]int{1}, 1.5); err == nil {
+		t.Error("Bernoulli: expected error for invalid probability")
+	}
+	if _, err := r.Multinomial(mustNewTensor(t, []int{2}, []float64{1, 0}), 2, false); err == nil {
+		t.Error("Multinomial: expected error drawing too many categories")
+	}
+	if _, err := r.Shuffle(NewScalar(1)); err == nil {
+		t.Error("Shuffle: expected error for scalar")
+	}
+}
+
+// TestRNGSplit tests that split generators are reproducible and independent
+func TestRNGSplit(t *testing.T) {
+	a := NewRNG(3)
+	b := NewRNG(3)
+
+	childA, _ := a.Split().Rand([]int{4})
+	childB, _ := b.Split().Rand([]int{4})
+	checkEqual(t, "Split Data", childA.Data(), childB.Data())
+
+	parent, _ := a.Rand([]int{4})
+	if almostEqual(parent.Data(), childA.Data()) {
+		t.Error("Expected split stream to differ from its parent")
+	}
+}
+
+// TestMultinomial tests drawing categories from weights
+func TestMultinomial(t *testing.T) {
+	r := NewRNG(5)
+
+	draws, err := r.Multinomial(mustNewTensor(t, []int{3}, []float64{0, 1, 0}), 4, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "DType", Int64, draws.DType())
+	checkEqual(t, "Draws", []float64{1, 1, 1, 1}, draws.Data())
+
+	unique, err := r.Multinomial(mustNewTensor(t, []int{3}, []float64{1, 2, 3}), 3, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	values := unique.Data()
+	sort.Float64s(values)
+	checkEqual(t, "Unique Draws", []float64{0, 1, 2}, values)
+}
+
+// TestShuffle tests shuffling rows of a tensor
+func TestShuffle(t *testing.T) {
+	tensor := mustNewTensor(t, []int{3, 2}, []float64{0, 0, 1, 1, 2, 2})
+	shuffled, err := NewRNG(9).Shuffle(tensor)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Shape", []int{3, 2}, shuffled.Shape())
+
+	// Every row must stay intact
+	data := shuffled.Data()
+	rows := make([]float64, 3)
+	for i := range rows {
+		if data[2*i] != data[2*i+1] {
+			t.Fatalf("Row %d was split: %v", i, data)
+		}
+		rows[i] = data[2*i]
+	}
+	sort.Float64s(rows)
+	checkEqual(t, "Rows", []float64{0, 1, 2}, rows)
+}
@@ -0,0 +1,600 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// reduction describes how an operand is split into the axes that are kept
+// and the axes that are reduced
+type reduction struct {
+	shape         []int
+	keptShape     []int
+	keptStride    []int
+	reducedShape  []int
+	reducedStride []int
+	offset        int
+	data          storage
+}
+
+// newReduction plans reducing x over the given axes, nil or empty axes reduce
+// over every axis, with keepDims the reduced axes are kept with size 1
+func newReduction(x Operand, axes []int, keepDims bool) (*reduction, error) {
+	shape := x.Shape()
+	stride := x.Stride()
+
+	// Mark the reduced axes, reducing every axis when none are given
+	reduced := make([]bool, len(shape))
+	for _, axis := range axes {
+		normalized, err := normalizeAxis(axis, len(shape))
+		if err != nil {
+			return nil, err
+		}
+		if reduced[normalized] {
+			return nil, fmt.Errorf("axis %d repeated in reduction axes %v", axis, axes)
+		}
+		reduced[normalized] = true
+	}
+	if len(axes) == 0 {
+		for i := range reduced {
+			reduced[i] = true
+		}
+	}
+
+	// Split the shape and strides into kept and reduced axes
+	r := &reduction{
+		shape:         []int{},
+		keptShape:     []int{},
+		keptStride:    []int{},
+		reducedShape:  []int{},
+		reducedStride: []int{},
+		offset:        x.Offset(),
+		data:          x.storage(),
+	}
+	for i, dim := range shape {
+		if reduced[i] {
+			r.reducedShape = append(r.reducedShape, dim)
+			r.reducedStride = append(r.reducedStride, stride[i])
+			if keepDims {
+				r.shape = append(r.shape, 1)
+			}
+		} else {
+			r.keptShape = append(r.keptShape, dim)
+			r.keptStride = append(r.keptStride, stride[i])
+			r.shape = append(r.shape, dim)
+		}
+	}
+
+	// Return the reduction
+	return r, nil
+}
+
+// size returns the number of elements reduced into each result element
+func (r *reduction) size() int {
+	return shapeSize(r.reducedShape)
+}
+
+// reduceBlock is the number of elements of a group applyBlocks reduces
+// together, it is fixed so the blocks and the order their results are
+// combined in do not depend on the number of threads
+const reduceBlock = 1 << 12
+
+// readGroup fills values with the elements of group i of r from its start-th
+// element on, in row-major order of the reduced axes, read with get, idx is
+// scratch space for the index into the reduced axes
+func readGroup[T float64 | int64](r *reduction, get func(i int) T, i, start int, values []T, idx []int) {
+	if len(values) == 0 {
+		return
+	}
+
+	// Find the start of the group and the index of its start-th element
+	offset := r.offset
+	for j := len(r.keptShape) - 1; j >= 0; j-- {
+		offset += (i % r.keptShape[j]) * r.keptStride[j]
+		i /= r.keptShape[j]
+	}
+	for j := len(r.reducedShape) - 1; j >= 0; j-- {
+		idx[j] = start % r.reducedShape[j]
+		start /= r.reducedShape[j]
+	}
+
+	// Read the elements
+	for j := range values {
+		at := offset
+		for k, v := range idx {
+			at += v * r.reducedStride[k]
+		}
+		values[j] = get(at)
+		nextIndex(idx, r.reducedShape)
+	}
+}
+
+// apply reduces the elements of every group with fn, fn receives the group's
+// elements in row-major order of the reduced axes
+func (r *reduction) apply(dtype DType, fn func(values []float64) float64) *TensorStruct {
+	// Initialize the result
+	result := newStorage(dtype, shapeSize(r.keptShape))
+	groups, size := result.len(), r.size()
+
+	// With fewer groups than threads, read each group across the workers
+	if groups < NumThreads() {
+		values := make([]float64, size)
+		for i := 0; i < groups; i++ {
+			parallelFor(size, 1, func(start, end int) {
+				readGroup(r, r.data.get, i, start, values[start:end], make([]int, len(r.reducedShape)))
+			})
+			result.set(i, fn(values))
+		}
+		return newTensor(r.shape, result)
+	}
+
+	// Otherwise reduce the groups across the workers, every group is reduced
+	// whole by one worker so the result does not depend on the number of threads
+	parallelFor(groups, size, func(start, end int) {
+		values := make([]float64, size)
+		idx := make([]int, len(r.reducedShape))
+		for i := start; i < end; i++ {
+			readGroup(r, r.data.get, i, 0, values, idx)
+			result.set(i, fn(values))
+		}
+	})
+
+	// Return the result
+	return newTensor(r.shape, result)
+}
+
+// applyBlocks reduces every group by reducing each of its blocks of
+// reduceBlock elements with partial and then the results of the blocks in
+// order with combine, the blocks of all groups are split across the workers
+func (r *reduction) applyBlocks(dtype DType, partial, combine func(values []float64) float64) *TensorStruct {
+	result := newStorage(dtype, shapeSize(r.keptShape))
+	reduceGroupBlocks(r, r.data.get, result.set, result.len(), partial, combine)
+	return newTensor(r.shape, result)
+}
+
+// applyExactBlocks is applyBlocks on the elements read as int64, so integer
+// reductions stay exact beyond 2^53
+func (r *reduction) applyExactBlocks(dtype DType, partial, combine func(values []int64) int64) *TensorStruct {
+	result := newStorage(dtype, shapeSize(r.keptShape))
+	reduceGroupBlocks(r, r.data.getInt, result.setInt, result.len(), partial, combine)
+	return newTensor(r.shape, result)
+}
+
+// reduceGroupBlocks reduces the groups of r in blocks for applyBlocks and
+// applyExactBlocks, reading elements with get and storing results with set
+func reduceGroupBlocks[T float64 | int64](r *reduction, get func(i int) T, set func(i int, v T), groups int, partial, combine func(values []T) T) {
+	size := r.size()
+	blocks := max(1, (size+reduceBlock-1)/reduceBlock)
+
+	// Reduce the blocks of every group
+	partials := make([]T, groups*blocks)
+	parallelFor(len(partials), min(size, reduceBlock), func(start, end int) {
+		values := make([]T, min(size, reduceBlock))
+		idx := make([]int, len(r.reducedShape))
+		for i := start; i < end; i++ {
+			first := (i % blocks) * reduceBlock
+			block := values[:min(reduceBlock, size-first)]
+			readGroup(r, get, i/blocks, first, block, idx)
+			partials[i] = partial(block)
+		}
+	})
+
+	// Combine the results of the blocks of every group in order
+	parallelFor(groups, blocks, func(start, end int) {
+		for i := start; i < end; i++ {
+			set(i, combine(partials[i*blocks:(i+1)*blocks]))
+		}
+	})
+}
+
+// reduce reduces x over the axes with fn, storing the result with the given dtype
+func reduce(x Operand, axes []int, keepDims bool, dtype DType, fn func(values []float64) float64) (*TensorStruct, error) {
+	r, err := newReduction(x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	return r.apply(dtype, fn), nil
+}
+
+// newNonEmptyReduction is newReduction for reductions that have no value for
+// an empty group
+func newNonEmptyReduction(name string, x Operand, axes []int, keepDims bool) (*reduction, error) {
+	r, err := newReduction(x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	if r.size() == 0 {
+		return nil, fmt.Errorf("cannot compute %s over an empty axis", name)
+	}
+	return r, nil
+}
+
+// reduceNonEmpty is reduce for reductions that have no value for an empty group
+func reduceNonEmpty(name string, x Operand, axes []int, keepDims bool, dtype DType, fn func(values []float64) float64) (*TensorStruct, error) {
+	r, err := newNonEmptyReduction(name, x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	return r.apply(dtype, fn), nil
+}
+
+// reduceBlocks is reduce for reductions that can be split into blocks, partial
+// reduces each block and combine the results of a group's blocks
+func reduceBlocks(x Operand, axes []int, keepDims bool, dtype DType, partial, combine func(values []float64) float64) (*TensorStruct, error) {
+	r, err := newReduction(x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	return r.applyBlocks(dtype, partial, combine), nil
+}
+
+// sumValues returns the sum of the values
+func sumValues(values []float64) float64 {
+	total := 0.0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// prodValues returns the product of the values
+func prodValues(values []float64) float64 {
+	result := 1.0
+	for _, v := range values {
+		result *= v
+	}
+	return result
+}
+
+// sumInts returns the sum of the values, wrapping around on overflow
+func sumInts(values []int64) int64 {
+	total := int64(0)
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// prodInts returns the product of the values, wrapping around on overflow
+func prodInts(values []int64) int64 {
+	result := int64(1)
+	for _, v := range values {
+		result *= v
+	}
+	return result
+}
+
+// meanValues returns the mean of the values
+func meanValues(values []float64) float64 {
+	return sumValues(values) / float64(len(values))
+}
+
+// varValues returns the variance of the values with ddof delta degrees of freedom
+func varValues(values []float64, ddof int) float64 {
+	mean := meanValues(values)
+	total := 0.0
+	for _, v := range values {
+		total += (v - mean) * (v - mean)
+	}
+	if len(values)-ddof <= 0 {
+		return math.NaN()
+	}
+	return total / float64(len(values)-ddof)
+}
+
+// argBest returns the index of the first value better than all others,
+// NaN counts as better than any number
+func argBest(values []float64, better func(a, b float64) bool) float64 {
+	best := 0
+	for i, v := range values {
+		if math.IsNaN(values[best]) {
+			break
+		}
+		if math.IsNaN(v) || better(v, values[best]) {
+			best = i
+		}
+	}
+	return float64(best)
+}
+
+// normBlocks returns the partial and combine functions of the p-norm, the
+// partial results are the largest or smallest absolute value, the number of
+// non-zero values or the sum of the absolute values raised to p
+func normBlocks(p float64) (func(values []float64) float64, func(values []float64) float64) {
+	switch {
+	case math.IsInf(p, 1):
+		// Largest absolute value
+		largest := func(values []float64) float64 {
+			result := 0.0
+			for _, v := range values {
+				result = math.Max(result, math.Abs(v))
+			}
+			return result
+		}
+		return largest, largest
+	case math.IsInf(p, -1):
+		// Smallest absolute value
+		smallest := func(values []float64) float64 {
+			result := math.Inf(1)
+			for _, v := range values {
+				result = math.Min(result, math.Abs(v))
+			}
+			return result
+		}
+		return smallest, smallest
+	case p == 0:
+		// Number of non-zero values
+		return func(values []float64) float64 {
+			count := 0.0
+			for _, v := range values {
+				if v != 0 {
+					count++
+				}
+			}
+			return count
+		}, sumValues
+	default:
+		// Root of the sum of the absolute values raised to p
+		powers := func(values []float64) float64 {
+			total := 0.0
+			for _, v := range values {
+				total += math.Pow(math.Abs(v), p)
+			}
+			return total
+		}
+		return powers, func(totals []float64) float64 {
+			return math.Pow(sumValues(totals), 1/p)
+		}
+	}
+}
+
+// sum reduces x by summing over the axes
+func sum(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	r, err := newReduction(x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	dtype := arithmeticType(x.DType(), x.DType())
+	if isExact(x.DType()) {
+		return r.applyExactBlocks(dtype, sumInts, sumInts), nil
+	}
+	return r.applyBlocks(dtype, sumValues, sumValues), nil
+}
+
+// mean reduces x by averaging over the axes
+func mean(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	r, err := newReduction(x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	return r.applyBlocks(divisionType(x.DType(), x.DType()), sumValues, func(totals []float64) float64 {
+		return sumValues(totals) / float64(r.size())
+	}), nil
+}
+
+// prod reduces x by multiplying over the axes
+func prod(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	r, err := newReduction(x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	dtype := arithmeticType(x.DType(), x.DType())
+	if isExact(x.DType()) {
+		return r.applyExactBlocks(dtype, prodInts, prodInts), nil
+	}
+	return r.applyBlocks(dtype, prodValues, prodValues), nil
+}
+
+// maxOf reduces x to its largest values over the axes
+func maxOf(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	r, err := newNonEmptyReduction("max", x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	if isExact(x.DType()) {
+		return r.applyExactBlocks(x.DType(), slices.Max[[]int64], slices.Max[[]int64]), nil
+	}
+	best := func(values []float64) float64 {
+		return values[int(argBest(values, func(a, b float64) bool { return a > b }))]
+	}
+	return r.applyBlocks(x.DType(), best, best), nil
+}
+
+// minOf reduces x to its smallest values over the axes
+func minOf(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	r, err := newNonEmptyReduction("min", x, axes, keepDims)
+	if err != nil {
+		return nil, err
+	}
+	if isExact(x.DType()) {
+		return r.applyExactBlocks(x.DType(), slices.Min[[]int64], slices.Min[[]int64]), nil
+	}
+	best := func(values []float64) float64 {
+		return values[int(argBest(values, func(a, b float64) bool { return a < b }))]
+	}
+	return r.applyBlocks(x.DType(), best, best), nil
+}
+
+// argReduce reduces x to the index of the best value along at most one axis,
+// with no axis the index is into the row-major elements of x
+func argReduce(name string, x Operand, axes []int, keepDims bool, better func(a, b float64) bool) (*TensorStruct, error) {
+	if len(axes) > 1 {
+		return nil, fmt.Errorf("%s takes at most one axis, got %v", name, axes)
+	}
+	return reduceNonEmpty(name, x, axes, keepDims, Int64, func(values []float64) float64 {
+		return argBest(values, better)
+	})
+}
+
+// variance reduces x to its variance over the axes
+func variance(x Operand, axes []int, ddof int, keepDims bool) (*TensorStruct, error) {
+	return reduce(x, axes, keepDims, divisionType(x.DType(), x.DType()), func(values []float64) float64 {
+		return varValues(values, ddof)
+	})
+}
+
+// std reduces x to its standard deviation over the axes
+func std(x Operand, axes []int, ddof int, keepDims bool) (*TensorStruct, error) {
+	return reduce(x, axes, keepDims, divisionType(x.DType(), x.DType()), func(values []float64) float64 {
+		return math.Sqrt(varValues(values, ddof))
+	})
+}
+
+// norm reduces x to its p-norm over the axes
+func norm(x Operand, p float64, axes []int, keepDims bool) (*TensorStruct, error) {
+	partial, combine := normBlocks(p)
+	return reduceBlocks(x, axes, keepDims, divisionType(x.DType(), x.DType()), partial, combine)
+}
+
+// all reduces x to whether every value over the axes is non-zero
+func all(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	every := func(values []float64) float64 {
+		for _, v := range values {
+			if v == 0 {
+				return 0
+			}
+		}
+		return 1
+	}
+	return reduceBlocks(x, axes, keepDims, Bool, every, every)
+}
+
+// anyOf reduces x to whether any value over the axes is non-zero
+func anyOf(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	some := func(values []float64) float64 {
+		for _, v := range values {
+			if v != 0 {
+				return 1
+			}
+		}
+		return 0
+	}
+	return reduceBlocks(x, axes, keepDims, Bool, some, some)
+}
+
+// Sum returns the sum over the axes, nil axes reduce over every axis, with
+// keepDims the reduced axes are kept with size 1
+func (t *TensorStruct) Sum(axes []int, keepDims bool) (*TensorStruct, error) {
+	return sum(t, axes, keepDims)
+}
+
+// Mean returns the mean over the axes
+func (t *TensorStruct) Mean(axes []int, keepDims bool) (*TensorStruct, error) {
+	return mean(t, axes, keepDims)
+}
+
+// Prod returns the product over the axes
+func (t *TensorStruct) Prod(axes []int, keepDims bool) (*TensorStruct, error) {
+	return prod(t, axes, keepDims)
+}
+
+// Max returns the largest values over the axes
+func (t *TensorStruct) Max(axes []int, keepDims bool) (*TensorStruct, error) {
+	return maxOf(t, axes, keepDims)
+}
+
+// Min returns the smallest values over the axes
+func (t *TensorStruct) Min(axes []int, keepDims bool) (*TensorStruct, error) {
+	return minOf(t, axes, keepDims)
+}
+
+// ArgMax returns the indices of the first largest values along at most one
+// axis, with no axis the index is into the row-major elements of the tensor
+func (t *TensorStruct) ArgMax(axes []int, keepDims bool) (*TensorStruct, error) {
+	return argReduce("argmax", t, axes, keepDims, func(a, b float64) bool { return a > b })
+}
+
+// ArgMin returns the indices of the first smallest values along at most one
+// axis, with no axis the index is into the row-major elements of the tensor
+func (t *TensorStruct) ArgMin(axes []int, keepDims bool) (*TensorStruct, error) {
+	return argReduce("argmin", t, axes, keepDims, func(a, b float64) bool { return a < b })
+}
+
+// Var returns the variance over the axes, divided by the number of elements minus ddof
+func (t *TensorStruct) Var(axes []int, ddof int, keepDims bool) (*TensorStruct, error) {
+	return variance(t, axes, ddof, keepDims)
+}
+
+// Std returns the standard deviation over the axes, see Var
+func (t *TensorStruct) Std(axes []int, ddof int, keepDims bool) (*TensorStruct, error) {
+	return std(t, axes, ddof, keepDims)
+}
+
+// Norm returns the p-norm over the axes, p may be 0 or ±Inf
+func (t *TensorStruct) Norm(p float64, axes []int, keepDims bool) (*TensorStruct, error) {
+	return norm(t, p, axes, keepDims)
+}
+
+// All returns whether every value over the axes is non-zero
+func (t *TensorStruct) All(axes []int, keepDims bool) (*TensorStruct, error) {
+	return all(t, axes, keepDims)
+}
+
+// Any returns whether any value over the axes is non-zero
+func (t *TensorStruct) Any(axes []int, keepDims bool) (*TensorStruct, error) {
+	return anyOf(t, axes, keepDims)
+}
+
+// Sum returns the sum over the axes, nil axes reduce over every axis, with
+// keepDims the reduced axes are kept with size 1
+func (v *ViewStruct) Sum(axes []int, keepDims bool) (*TensorStruct, error) {
+	return sum(v, axes, keepDims)
+}
+
+// Mean returns the mean over the axes
+func (v *ViewStruct) Mean(axes []int, keepDims bool) (*TensorStruct, error) {
+	return mean(v, axes, keepDims)
+}
+
+// Prod returns the product over the axes
+func (v *ViewStruct) Prod(axes []int, keepDims bool) (*TensorStruct, error) {
+	return prod(v, axes, keepDims)
+}
+
+// Max returns the largest values over the axes
+func (v *ViewStruct) Max(axes []int, keepDims bool) (*TensorStruct, error) {
+	return maxOf(v, axes, keepDims)
+}
+
+// Min returns the smallest values over the axes
+func (v *ViewStruct) Min(axes []int, keepDims bool) (*TensorStruct, error) {
+	return minOf(v, axes, keepDims)
+}
+
+// ArgMax returns the indices of the first largest values along at most one
+// axis, with no axis the index is into the row-major elements of the view
+func (v *ViewStruct) ArgMax(axes []int, keepDims bool) (*TensorStruct, error) {
+	return argReduce("argmax", v, axes, keepDims, func(a, b float64) bool { return a > b })
+}
+
+// ArgMin returns the indices of the first smallest values along at most one
+// axis, with no axis the index is into the row-major elements of the view
+func (v *ViewStruct) ArgMin(axes []int, keepDims bool) (*TensorStruct, error) {
+	return argReduce("argmin", v, axes, keepDims, func(a, b float64) bool { return a < b })
+}
+
+// Var returns the variance over the axes, divided by the number of elements minus ddof
+func (v *ViewStruct) Var(axes []int, ddof int, keepDims bool) (*TensorStruct, error) {
+	return variance(v, axes, ddof, keepDims)
+}
+
+// Std returns the standard deviation over the axes, see Var
+func (v *ViewStruct) Std(axes []int, ddof int, keepDims bool) (*TensorStruct, error) {
+	return std(v, axes, ddof, keepDims)
+}
+
+// Norm returns the p-norm over the axes, p may be 0 or ±Inf
+func (v *ViewStruct) Norm(p float64, axes []int, keepDims bool) (*TensorStruct, error) {
+	return norm(v, p, axes, keepDims)
+}
+
+// All returns whether every value over the axes is non-zero
+func (v *ViewStruct) All(axes []int, keepDims bool) (*TensorStruct, error) {
+	return all(v, axes, keepDims)
+}
+
+// Any returns whether any value over the axes is non-zero
+func (v *ViewStruct) Any(axes []int, keepDims bool) (*TensorStruct, error) {
+	return anyOf(v, axes, keepDims)
+}
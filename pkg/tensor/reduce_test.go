@@ -0,0 +1,150 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+// TestReductions tests reducing tensors over axes
+func TestReductions(t *testing.T) {
+	// [[1 2 3]
+	//  [4 5 6]]
+	matrix := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+		expectErr     bool
+	}{
+		{"SumAll", func() (*TensorStruct, error) { return matrix.Sum(nil, false) }, []int{}, []float64{21}, false},
+		{"SumRows", func() (*TensorStruct, error) { return matrix.Sum([]int{0}, false) }, []int{3}, []float64{5, 7, 9}, false},
+		{"SumColumns", func() (*TensorStruct, error) { return matrix.Sum([]int{1}, false) }, []int{2}, []float64{6, 15}, false},
+		{"SumKeepDims", func() (*TensorStruct, error) { return matrix.Sum([]int{-1}, true) }, []int{2, 1}, []float64{6, 15}, false},
+		{"SumAllKeepDims", func() (*TensorStruct, error) { return matrix.Sum([]int{0, 1}, true) }, []int{1, 1}, []float64{21}, false},
+		{"SumRepeatedAxis", func() (*TensorStruct, error) { return matrix.Sum([]int{0, 0}, false) }, nil, nil, true},
+		{"SumBadAxis", func() (*TensorStruct, error) { return matrix.Sum([]int{2}, false) }, nil, nil, true},
+		{"Mean", func() (*TensorStruct, error) { return matrix.Mean([]int{1}, false) }, []int{2}, []float64{2, 5}, false},
+		{"Prod", func() (*TensorStruct, error) { return matrix.Prod([]int{0}, false) }, []int{3}, []float64{4, 10, 18}, false},
+		{"Max", func() (*TensorStruct, error) { return matrix.Max([]int{0}, false) }, []int{3}, []float64{4, 5, 6}, false},
+		{"Min", func() (*TensorStruct, error) { return matrix.Min(nil, false) }, []int{}, []float64{1}, false},
+		{"ArgMaxAll", func() (*TensorStruct, error) { return matrix.ArgMax(nil, false) }, []int{}, []float64{5}, false},
+		{"ArgMaxColumns", func() (*TensorStruct, error) { return matrix.ArgMax([]int{1}, true) }, []int{2, 1}, []float64{2, 2}, false},
+		{"ArgMin", func() (*TensorStruct, error) { return matrix.ArgMin([]int{0}, false) }, []int{3}, []float64{0, 0, 0}, false},
+		{"ArgMaxTwoAxes", func() (*TensorStruct, error) { return matrix.ArgMax([]int{0, 1}, false) }, nil, nil, true},
+		{"Var", func() (*TensorStruct, error) { return matrix.Var([]int{1}, 0, false) }, []int{2}, []float64{2.0 / 3.0, 2.0 / 3.0}, false},
+		{"VarSample", func() (*TensorStruct, error) { return matrix.Var([]int{1}, 1, false) }, []int{2}, []float64{1, 1}, false},
+		{"Std", func() (*TensorStruct, error) { return matrix.Std([]int{0}, 0, false) }, []int{3}, []float64{1.5, 1.5, 1.5}, false},
+		{"Norm2", func() (*TensorStruct, error) { return matrix.Norm(2, []int{0}, false) }, []int{3}, []float64{math.Sqrt(17), math.Sqrt(29), math.Sqrt(45)}, false},
+		{"Norm1", func() (*TensorStruct, error) { return matrix.Norm(1, nil, false) }, []int{}, []float64{21}, false},
+		{"NormInf", func() (*TensorStruct, error) { return matrix.Norm(math.Inf(1), []int{1}, false) }, []int{2}, []float64{3, 6}, false},
+		{"All", func() (*TensorStruct, error) { return matrix.All(nil, false) }, []int{}, []float64{1}, false},
+		{"Any", func() (*TensorStruct, error) { return matrix.Any([]int{1}, false) }, []int{2}, []float64{1, 1}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			if !almostEqual(tc.expectedData, result.Data()) {
+				t.Errorf("Expected data %v, got %v", tc.expectedData, result.Data())
+			}
+		})
+	}
+}
+
+// TestReductionDTypes tests the dtypes produced by reductions
+func TestReductionDTypes(t *testing.T) {
+	ints, _ := NewTensorOf([]int{3}, []int32{1, 2, 4})
+	mask, _ := NewTensorOf([]int{3}, []bool{true, false, true})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedDType DType
+		expectedData  []float64
+	}{
+		{"SumInt", func() (*TensorStruct, error) { return ints.Sum(nil, false) }, Int32, []float64{7}},
+		{"MeanInt", func() (*TensorStruct, error) { return ints.Mean(nil, false) }, Float64, []float64{7.0 / 3.0}},
+		{"MaxInt", func() (*TensorStruct, error) { return ints.Max(nil, false) }, Int32, []float64{4}},
+		{"ArgMax", func() (*TensorStruct, error) { return ints.ArgMax(nil, false) }, Int64, []float64{2}},
+		{"SumBool", func() (*TensorStruct, error) { return mask.Sum(nil, false) }, Int64, []float64{2}},
+		{"AllBool", func() (*TensorStruct, error) { return mask.All(nil, false) }, Bool, []float64{0}},
+		{"AnyBool", func() (*TensorStruct, error) { return mask.Any(nil, false) }, Bool, []float64{1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "DType", tc.expectedDType, result.DType())
+			if !almostEqual(tc.expectedData, result.Data()) {
+				t.Errorf("Expected data %v, got %v", tc.expectedData, result.Data())
+			}
+		})
+	}
+}
+
+// TestReduceViews tests reducing strided views without copying
+func TestReduceViews(t *testing.T) {
+	matrix := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	// Summing the first axis of the transpose sums the rows of the matrix
+	sum, err := matrix.Transpose().Sum([]int{0}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Transposed Sum", []float64{6, 15}, sum.Data())
+
+	// Reductions read through the offset and strides of a slice
+	column, _ := matrix.Slice(All(), Step(End, End, -2))
+	largest, err := column.Max([]int{1}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Slice Max", []float64{3, 6}, largest.Data())
+
+	argmax, err := column.ArgMax([]int{1}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Slice ArgMax", []float64{0, 0}, argmax.Data())
+}
+
+// TestReduceEdgeCases tests reductions of empty and special values
+func TestReduceEdgeCases(t *testing.T) {
+	empty, _ := Zeros([]int{0, 2})
+
+	sum, err := empty.Sum([]int{0}, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Empty Sum", []float64{0, 0}, sum.Data())
+
+	prod, _ := empty.Prod([]int{0}, false)
+	checkEqual(t, "Empty Prod", []float64{1, 1}, prod.Data())
+
+	if _, err := empty.Max([]int{0}, false); err == nil {
+		t.Error("Expected error for max over an empty axis")
+	}
+
+	// NaN propagates through max
+	withNaN := mustNewTensor(t, []int{3}, []float64{1, math.NaN(), 3})
+	largest, _ := withNaN.Max(nil, false)
+	if !math.IsNaN(largest.Data()[0]) {
+		t.Errorf("Expected NaN max, got %v", largest.Data()[0])
+	}
+
+	// Reducing a scalar returns the scalar
+	scalar, _ := NewScalar(4).Sum(nil, false)
+	checkEqual(t, "Scalar Sum", []float64{4}, scalar.Data())
+}
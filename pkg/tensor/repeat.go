@@ -0,0 +1,136 @@
+package tensor
+
+import (
+	"fmt"
+)
+
+// NewTensorFill creates a new float64 tensor with the given shape, filled by
+// cycling through the pattern as many times as needed
+func NewTensorFill(shape []int, pattern []float64) (*TensorStruct, error) {
+	// Check the shape
+	if err := validShape(shape); err != nil {
+		return nil, err
+	}
+
+	// Check if the pattern is empty
+	if len(pattern) == 0 {
+		return nil, fmt.Errorf("no pattern provided")
+	}
+
+	// Check if the pattern is too long for the shape
+	size := shapeSize(shape)
+	if len(pattern) > size {
+		return nil, fmt.Errorf("pattern length %d exceeds shape capacity %d", len(pattern), size)
+	}
+
+	// Cycle the pattern through the data
+	data := allocFloat64s(size)
+	for i := range data {
+		data[i] = pattern[i%len(pattern)]
+	}
+
+	// Return the tensor
+	return newTensor(shape, float64Storage(data)), nil
+}
+
+// remap creates a tensor of the given shape and the dtype of t, source maps
+// each index of the result to the index of t the element is copied from
+func (t *TensorStruct) remap(shape []int, source func(idx []int, src []int)) *TensorStruct {
+	// Initialize the result
+	data := newStorage(t.DType(), shapeSize(shape))
+
+	// Copy every element from its source index
+	idx := make([]int, len(shape))
+	src := make([]int, len(t.shape))
+	for i := 0; i < data.len(); i++ {
+		source(idx, src)
+		offset := 0
+		for j, v := range src {
+			offset += v * t.stride[j]
+		}
+		copyElement(data, i, t.data, offset)
+		nextIndex(idx, shape)
+	}
+
+	// Return the tensor
+	return newTensor(shape, data)
+}
+
+// Tile returns a tensor made of t repeated reps[i] times along each axis i,
+// when reps and the shape have different lengths the shorter is padded with
+// leading ones
+func (t *TensorStruct) Tile(reps []int) (*TensorStruct, error) {
+	// Check the repetitions
+	for i, n := range reps {
+		if n < 0 {
+			return nil, fmt.Errorf("invalid repetitions %d at axis %d", n, i)
+		}
+	}
+
+	// Pad the shape and repetitions to the same rank
+	rank := max(len(reps), len(t.shape))
+	srcShape := make([]int, rank)
+	paddedReps := make([]int, rank)
+	for i := 0; i < rank; i++ {
+		srcShape[i], paddedReps[i] = 1, 1
+	}
+	copy(srcShape[rank-len(t.shape):], t.shape)
+	copy(paddedReps[rank-len(reps):], reps)
+
+	// Calculate the result shape
+	shape := make([]int, rank)
+	for i := range shape {
+		shape[i] = srcShape[i] * paddedReps[i]
+	}
+
+	// Copy the tiles, each result index wraps around the source shape
+	pad := rank - len(t.shape)
+	return t.remap(shape, func(idx []int, src []int) {
+		for i := range src {
+			src[i] = idx[pad+i] % t.shape[i]
+		}
+	}), nil
+}
+
+// Repeat returns a tensor made of n copies of t placed one after another along the axis
+func (t *TensorStruct) Repeat(n int, axis int) (*TensorStruct, error) {
+	// Check the axis
+	axis, err := normalizeAxis(axis, t.Rank())
+	if err != nil {
+		return nil, err
+	}
+
+	// Tile along the single axis
+	reps := make([]int, t.Rank())
+	for i := range reps {
+		reps[i] = 1
+	}
+	reps[axis] = n
+	return t.Tile(reps)
+}
+
+// RepeatInterleave returns a tensor where every element of t is repeated n
+// times in a row along the axis
+func (t *TensorStruct) RepeatInterleave(n int, axis int) (*TensorStruct, error) {
+	// Check the axis
+	axis, err := normalizeAxis(axis, t.Rank())
+	if err != nil {
+		return nil, err
+	}
+
+	// Check the repetitions
+	if n < 0 {
+		return nil, fmt.Errorf("invalid repetitions %d at axis %d", n, axis)
+	}
+
+	// Calculate the result shape
+	shape := make([]int, t.Rank())
+	copy(shape, t.shape)
+	shape[axis] *= n
+
+	// Copy the elements, each source element covers n result indices along the axis
+	return t.remap(shape, func(idx []int, src []int) {
+		copy(src, idx)
+		src[axis] = idx[axis] / n
+	}), nil
+}
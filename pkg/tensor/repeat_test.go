@@ -0,0 +1,78 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// TestNewTensorFill tests filling a tensor from a repeating pattern
+func TestNewTensorFill(t *testing.T) {
+	testCases := []struct {
+		name         string
+		shape        []int
+		pattern      []float64
+		expectedData []float64
+		expectErr    bool
+	}{
+		{"SingleValue", []int{2, 2}, []float64{7}, []float64{7, 7, 7, 7}, false},
+		{"EvenPattern", []int{2, 3}, []float64{1, 2, 3}, []float64{1, 2, 3, 1, 2, 3}, false},
+		{"UnevenPattern", []int{5}, []float64{1, 2}, []float64{1, 2, 1, 2, 1}, false},
+		{"FullData", []int{2}, []float64{1, 2}, []float64{1, 2}, false},
+		{"EmptyPattern", []int{2}, []float64{}, nil, true},
+		{"PatternTooLong", []int{2}, []float64{1, 2, 3}, nil, true},
+		{"NegativeShape", []int{-2}, []float64{1}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tensor, err := NewTensorFill(tc.shape, tc.pattern)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.shape, tensor.Shape())
+			checkEqual(t, "Data", tc.expectedData, tensor.Data())
+		})
+	}
+}
+
+// TestReplication tests the Tile, Repeat and RepeatInterleave operations
+func TestReplication(t *testing.T) {
+	matrix := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+	vector := mustNewTensor(t, []int{2}, []float64{1, 2})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+		expectErr     bool
+	}{
+		{"TileBothAxes", func() (*TensorStruct, error) { return matrix.Tile([]int{2, 2}) }, []int{4, 4}, []float64{1, 2, 1, 2, 3, 4, 3, 4, 1, 2, 1, 2, 3, 4, 3, 4}, false},
+		{"TileShortReps", func() (*TensorStruct, error) { return matrix.Tile([]int{2}) }, []int{2, 4}, []float64{1, 2, 1, 2, 3, 4, 3, 4}, false},
+		{"TileLongReps", func() (*TensorStruct, error) { return vector.Tile([]int{2, 1}) }, []int{2, 2}, []float64{1, 2, 1, 2}, false},
+		{"TileScalar", func() (*TensorStruct, error) { return NewScalar(5).Tile([]int{3}) }, []int{3}, []float64{5, 5, 5}, false},
+		{"TileNegative", func() (*TensorStruct, error) { return vector.Tile([]int{-1}) }, nil, nil, true},
+		{"RepeatRows", func() (*TensorStruct, error) { return matrix.Repeat(2, 0) }, []int{4, 2}, []float64{1, 2, 3, 4, 1, 2, 3, 4}, false},
+		{"RepeatLastAxis", func() (*TensorStruct, error) { return matrix.Repeat(2, -1) }, []int{2, 4}, []float64{1, 2, 1, 2, 3, 4, 3, 4}, false},
+		{"RepeatBadAxis", func() (*TensorStruct, error) { return matrix.Repeat(2, 2) }, nil, nil, true},
+		{"InterleaveRows", func() (*TensorStruct, error) { return matrix.RepeatInterleave(2, 0) }, []int{4, 2}, []float64{1, 2, 1, 2, 3, 4, 3, 4}, false},
+		{"InterleaveColumns", func() (*TensorStruct, error) { return matrix.RepeatInterleave(3, 1) }, []int{2, 6}, []float64{1, 1, 1, 2, 2, 2, 3, 3, 3, 4, 4, 4}, false},
+		{"InterleaveNegative", func() (*TensorStruct, error) { return matrix.RepeatInterleave(-1, 1) }, nil, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			checkEqual(t, "Data", tc.expectedData, result.Data())
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// scan applies fn to every lane of x along axis, fn fills a lane of the
+// result of the same shape with the given dtype
+func scan[T float64 | int64](name string, x Operand, axis int, dtype DType, fn func(values []T, output []T)) (*TensorStruct, error) {
+	if len(x.Shape()) == 0 {
+		return nil, fmt.Errorf("cannot compute %s of a scalar", name)
+	}
+	normalized, err := normalizeAxis(axis, len(x.Shape()))
+	if err != nil {
+		return nil, err
+	}
+	results := mapLanes(x, normalized, x.Shape()[normalized], []DType{dtype}, func(values []T, outputs [][]T) {
+		fn(values, outputs[0])
+	})
+	return results[0], nil
+}
+
+// accumulate returns the scan that combines each element with the running
+// result of the elements before it
+func accumulate[T float64 | int64](combine func(running, v T) T) func(values []T, output []T) {
+	return func(values []T, output []T) {
+		for i, v := range values {
+			if i > 0 {
+				v = combine(output[i-1], v)
+			}
+			output[i] = v
+		}
+	}
+}
+
+// runningSum adds an element to the running sum
+func runningSum[T float64 | int64](running, v T) T {
+	return running + v
+}
+
+// runningProduct multiplies the running product by an element
+func runningProduct[T float64 | int64](running, v T) T {
+	return running * v
+}
+
+// runningMax returns the larger of the running maximum and an element, a NaN
+// is kept once reached, running != running only holds for NaN
+func runningMax[T float64 | int64](running, v T) T {
+	if running != running || running >= v {
+		return running
+	}
+	return v
+}
+
+// runningMin returns the smaller of the running minimum and an element, a NaN
+// is kept once reached, running != running only holds for NaN
+func runningMin[T float64 | int64](running, v T) T {
+	if running != running || running <= v {
+		return running
+	}
+	return v
+}
+
+// logAddExp returns log(exp(a) + exp(b)) without overflowing
+func logAddExp(a, b float64) float64 {
+	if a == b {
+		return a + math.Ln2
+	}
+	high, low := math.Max(a, b), math.Min(a, b)
+	if math.IsInf(low, -1) {
+		return high
+	}
+	return high + math.Log1p(math.Exp(low-high))
+}
+
+// cumSum returns the running sums of x along axis, integers are summed exactly
+func cumSum(x Operand, axis int) (*TensorStruct, error) {
+	dtype := arithmeticType(x.DType(), x.DType())
+	if isExact(x.DType()) {
+		return scan("cumulative sum", x, axis, dtype, accumulate(runningSum[int64]))
+	}
+	return scan("cumulative sum", x, axis, dtype, accumulate(runningSum[float64]))
+}
+
+// cumProd returns the running products of x along axis, integers are
+// multiplied exactly
+func cumProd(x Operand, axis int) (*TensorStruct, error) {
+	dtype := arithmeticType(x.DType(), x.DType())
+	if isExact(x.DType()) {
+		return scan("cumulative product", x, axis, dtype, accumulate(runningProduct[int64]))
+	}
+	return scan("cumulative product", x, axis, dtype, accumulate(runningProduct[float64]))
+}
+
+// cumMax returns the running maxima of x along axis, a NaN carries on to the
+// end of the axis
+func cumMax(x Operand, axis int) (*TensorStruct, error) {
+	if isExact(x.DType()) {
+		return scan("cumulative max", x, axis, x.DType(), accumulate(runningMax[int64]))
+	}
+	return scan("cumulative max", x, axis, x.DType(), accumulate(runningMax[float64]))
+}
+
+// cumMin returns the running minima of x along axis, a NaN carries on to the
+// end of the axis
+func cumMin(x Operand, axis int) (*TensorStruct, error) {
+	if isExact(x.DType()) {
+		return scan("cumulative min", x, axis, x.DType(), accumulate(runningMin[int64]))
+	}
+	return scan("cumulative min", x, axis, x.DType(), accumulate(runningMin[float64]))
+}
+
+// logCumSumExp returns the logarithms of the running sums of the exponentials
+// of x along axis, computed without overflowing
+func logCumSumExp(x Operand, axis int) (*TensorStruct, error) {
+	return scan("log cumulative sum exp", x, axis, floatType(x.DType()), accumulate(logAddExp))
+}
+
+// differences returns the lane filling the n-th differences of the values
+func differences[T float64 | int64](n int) func(values []T, outputs [][]T) {
+	return func(values []T, outputs [][]T) {
+		work := append([]T{}, values...)
+		for order := 0; order < n && len(work) > 0; order++ {
+			for i := 0; i+1 < len(work); i++ {
+				work[i] = work[i+1] - work[i]
+			}
+			work = work[:len(work)-1]
+		}
+		copy(outputs[0], work)
+	}
+}
+
+// diff returns the n-th differences of x along axis, each difference
+// shortens the axis by one and an axis shorter than n becomes empty
+func diff(x Operand, n int, axis int) (*TensorStruct, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid difference order: %d", n)
+	}
+	if len(x.Shape()) == 0 {
+		return nil, fmt.Errorf("cannot compute differences of a scalar")
+	}
+	normalized, err := normalizeAxis(axis, len(x.Shape()))
+	if err != nil {
+		return nil, err
+	}
+
+	// Differences of booleans are integers like other arithmetic on them
+	dtype := x.DType()
+	if n > 0 {
+		dtype = arithmeticType(dtype, dtype)
+	}
+	length := max(x.Shape()[normalized]-n, 0)
+	if isExact(x.DType()) {
+		return mapLanes(x, normalized, length, []DType{dtype}, differences[int64](n))[0], nil
+	}
+	return mapLanes(x, normalized, length, []DType{dtype}, differences[float64](n))[0], nil
+}
+
+// CumSum returns the running sums of the tensor along axis
+func (t *TensorStruct) CumSum(axis int) (*TensorStruct, error) {
+	return cumSum(t, axis)
+}
+
+// CumProd returns the running products of the tensor along axis
+func (t *TensorStruct) CumProd(axis int) (*TensorStruct, error) {
+	return cumProd(t, axis)
+}
+
+// CumMax returns the running maxima of the tensor along axis
+func (t *TensorStruct) CumMax(axis int) (*TensorStruct, error) {
+	return cumMax(t, axis)
+}
+
+// CumMin returns the running minima of the tensor along axis
+func (t *TensorStruct) CumMin(axis int) (*TensorStruct, error) {
+	return cumMin(t, axis)
+}
+
+// LogCumSumExp returns the logarithms of the running sums of the exponentials
+// of the tensor along axis
+func (t *TensorStruct) LogCumSumExp(axis int) (*TensorStruct, error) {
+	return logCumSumExp(t, axis)
+}
+
+// Diff returns the n-th differences of the tensor along axis
+func (t *TensorStruct) Diff(n int, axis int) (*TensorStruct, error) {
+	return diff(t, n, axis)
+}
+
+// CumSum returns the running sums of the view along axis
+func (v *ViewStruct) CumSum(axis int) (*TensorStruct, error) {
+	return cumSum(v, axis)
+}
+
+// CumProd returns the running products of the view along axis
+func (v *ViewStruct) CumProd(axis int) (*TensorStruct, error) {
+	return cumProd(v, axis)
+}
+
+// CumMax returns the running maxima of the view along axis
+func (v *ViewStruct) CumMax(axis int) (*TensorStruct, error) {
+	return cumMax(v, axis)
+}
+
+// CumMin returns the running minima of the view along axis
+func (v *ViewStruct) CumMin(axis int) (*TensorStruct, error) {
+	return cumMin(v, axis)
+}
+
+// LogCumSumExp returns the logarithms of the running sums of the exponentials
+// of the view along axis
+func (v *ViewStruct) LogCumSumExp(axis int) (*TensorStruct, error) {
+	return logCumSumExp(v, axis)
+}
+
+// Diff returns the n-th differences of the view along axis
+func (v *ViewStruct) Diff(n int, axis int) (*TensorStruct, error) {
+	return diff(v, n, axis)
+}
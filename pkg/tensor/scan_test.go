@@ -0,0 +1,114 @@
+package tensor
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestScans tests the cumulative operations and differences along an axis
+func TestScans(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	stepped, _ := x.Slice(All(), Step(0, End, 2))
+	reversed, _ := x.Slice(All(), Step(-1, End, -1))
+	squares := mustNewTensor(t, []int{4}, []float64{1, 4, 9, 16})
+	logs := mustNewTensor(t, []int{3}, []float64{0, 0, math.Log(2)})
+	large := mustNewTensor(t, []int{2}, []float64{1000, 1000})
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+	}{
+		{"CumSumRows", func() (*TensorStruct, error) { return x.CumSum(1) }, []int{2, 3}, []float64{1, 3, 6, 4, 9, 15}},
+		{"CumSumColumns", func() (*TensorStruct, error) { return x.CumSum(0) }, []int{2, 3}, []float64{1, 2, 3, 5, 7, 9}},
+		{"CumSumTransposed", func() (*TensorStruct, error) { return x.Transpose().CumSum(0) }, []int{3, 2}, []float64{1, 4, 3, 9, 6, 15}},
+		{"CumProd", func() (*TensorStruct, error) { return x.CumProd(-1) }, []int{2, 3}, []float64{1, 2, 6, 4, 20, 120}},
+		{"CumProdStepped", func() (*TensorStruct, error) { return stepped.CumProd(1) }, []int{2, 2}, []float64{1, 3, 4, 24}},
+		{"CumMax", func() (*TensorStruct, error) { return x.Transpose().CumMax(1) }, []int{3, 2}, []float64{1, 4, 2, 5, 3, 6}},
+		{"CumMin", func() (*TensorStruct, error) { return reversed.CumMin(1) }, []int{2, 3}, []float64{3, 2, 1, 6, 5, 4}},
+		{"LogCumSumExp", func() (*TensorStruct, error) { return logs.LogCumSumExp(0) }, []int{3}, []float64{0, math.Log(2), math.Log(4)}},
+		{"LogCumSumExpLarge", func() (*TensorStruct, error) { return large.LogCumSumExp(0) }, []int{2}, []float64{1000, 1000 + math.Log(2)}},
+		{"Diff", func() (*TensorStruct, error) { return x.Diff(1, 1) }, []int{2, 2}, []float64{1, 1, 1, 1}},
+		{"DiffColumns", func() (*TensorStruct, error) { return x.Diff(1, 0) }, []int{1, 3}, []float64{3, 3, 3}},
+		{"DiffSecond", func() (*TensorStruct, error) { return squares.Diff(2, 0) }, []int{2}, []float64{2, 2}},
+		{"DiffZero", func() (*TensorStruct, error) { return stepped.Diff(0, 1) }, []int{2, 2}, []float64{1, 3, 4, 6}},
+		{"DiffTooLong", func() (*TensorStruct, error) { return x.Diff(4, 1) }, []int{2, 0}, []float64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			if !almostEqual(tc.expectedData, result.Data()) {
+				t.Errorf("Expected %v, got %v", tc.expectedData, result.Data())
+			}
+		})
+	}
+}
+
+// TestScanNaN tests that a NaN carries on through the running extrema
+func TestScanNaN(t *testing.T) {
+	x := mustNewTensor(t, []int{5}, []float64{3, 1, math.NaN(), 0, 5})
+
+	for name, apply := range map[string]func(int) (*TensorStruct, error){"CumMax": x.CumMax, "CumMin": x.CumMin} {
+		result, err := apply(0)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		for i, v := range result.Data()[2:] {
+			if !math.IsNaN(v) {
+				t.Errorf("Expected %s NaN at %d, got %v", name, i+2, v)
+			}
+		}
+	}
+}
+
+// TestScanDTypes tests the dtypes of the cumulative operations
+func TestScanDTypes(t *testing.T) {
+	flags, _ := NewTensorOf([]int{3}, []bool{true, false, true})
+	ints, _ := NewTensorOf([]int{3}, []int32{5, 2, 7})
+
+	counts, _ := flags.CumSum(0)
+	checkEqual(t, "CumSum Bool", Int64, counts.DType())
+	checkEqual(t, "Counts", []float64{1, 1, 2}, counts.Data())
+
+	steps, _ := ints.Diff(1, 0)
+	checkEqual(t, "Diff Int32", Int32, steps.DType())
+	checkEqual(t, "Steps", []float64{-3, 5}, steps.Data())
+
+	peaks, _ := ints.CumMax(0)
+	checkEqual(t, "CumMax Int32", Int32, peaks.DType())
+
+	logs, _ := ints.LogCumSumExp(0)
+	checkEqual(t, "LogCumSumExp Int32", Float64, logs.DType())
+}
+
+// TestScanErrors tests that invalid arguments are reported
+func TestScanErrors(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	testCases := []struct {
+		name     string
+		apply    func() error
+		expected string
+	}{
+		{"Axis", func() error { _, err := x.CumSum(2); return err }, "out of range"},
+		{"Scalar", func() error { _, err := NewScalar(1).CumProd(0); return err }, "scalar"},
+		{"DiffOrder", func() error { _, err := x.Diff(-1, 0); return err }, "invalid difference order"},
+		{"DiffScalar", func() error { _, err := NewScalar(1).Diff(1, 0); return err }, "scalar"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.apply()
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package tensor
+
+import (
+	"fmt"
+)
+
+// inferShape returns shape with a -1 dimension replaced by the size it must
+// have to hold size elements, at most one dimension may be -1
+func inferShape(size int, shape []int) ([]int, error) {
+	// Find the inferred axis and the size of the others
+	inferred := -1
+	known := 1
+	for axis, dim := range shape {
+		switch {
+		case dim == -1 && inferred >= 0:
+			return nil, fmt.Errorf("only one dimension can be inferred, got -1 at axes %d and %d", inferred, axis)
+		case dim == -1:
+			inferred = axis
+		case dim < 0:
+			return nil, fmt.Errorf("invalid dimension %d at axis %d", dim, axis)
+		default:
+			known *= dim
+		}
+	}
+	if inferred < 0 {
+		return shape, nil
+	}
+
+	// Infer the dimension from the size of the others
+	if known == 0 || size%known != 0 {
+		return nil, fmt.Errorf("cannot infer axis %d of shape %v for size %d", inferred, shape, size)
+	}
+	result := append([]int{}, shape...)
+	result[inferred] = size / known
+	return result, nil
+}
+
+// Squeeze returns a view without the given axes, which must have size 1,
+// without axes every axis of size 1 is removed
+func (v *ViewStruct) Squeeze(axes ...int) (*ViewStruct, error) {
+	// Mark the removed axes
+	removed := make([]bool, len(v.shape))
+	for _, axis := range axes {
+		normalized, err := normalizeAxis(axis, len(v.shape))
+		if err != nil {
+			return nil, err
+		}
+		if v.shape[normalized] != 1 {
+			return nil, fmt.Errorf("cannot squeeze axis %d with size %d", axis, v.shape[normalized])
+		}
+		removed[normalized] = true
+	}
+	if len(axes) == 0 {
+		for i, dim := range v.shape {
+			removed[i] = dim == 1
+		}
+	}
+
+	// Keep the other axes with their strides
+	shape, stride := []int{}, []int{}
+	for i, dim := range v.shape {
+		if !removed[i] {
+			shape = append(shape, dim)
+			stride = append(stride, v.stride[i])
+		}
+	}
+	return &ViewStruct{shape: shape, stride: stride, offset: v.offset, tensor: v.tensor}, nil
+}
+
+// Unsqueeze returns a view with a new axis of size 1 inserted at axis, which
+// may be from -rank-1 to rank
+func (v *ViewStruct) Unsqueeze(axis int) (*ViewStruct, error) {
+	normalized, err := normalizeAxis(axis, len(v.shape)+1)
+	if err != nil {
+		return nil, err
+	}
+
+	// The stride of a size 1 axis is never used, give it the packed stride
+	newStride := 1
+	if normalized < len(v.shape) {
+		newStride = v.stride[normalized] * v.shape[normalized]
+	}
+	shape := append(append(append([]int{}, v.shape[:normalized]...), 1), v.shape[normalized:]...)
+	stride := append(append(append([]int{}, v.stride[:normalized]...), newStride), v.stride[normalized:]...)
+	return &ViewStruct{shape: shape, stride: stride, offset: v.offset, tensor: v.tensor}, nil
+}
+
+// Flatten merges the axes from start to end inclusive into one axis, copying
+// the elements when the view's strides don't allow merging them
+func (v *ViewStruct) Flatten(start, end int) (*ViewStruct, error) {
+	// A scalar flattens to a single axis
+	if len(v.shape) == 0 {
+		return v.Reshape([]int{1})
+	}
+
+	// Check the axes
+	first, err := normalizeAxis(start, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+	last, err := normalizeAxis(end, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+	if first > last {
+		return nil, fmt.Errorf("flatten start axis %d is after end axis %d", start, end)
+	}
+
+	// Merge the axes
+	shape := append([]int{}, v.shape[:first]...)
+	shape = append(shape, shapeSize(v.shape[first:last+1]))
+	shape = append(shape, v.shape[last+1:]...)
+	return v.Reshape(shape)
+}
+
+// Unflatten splits axis into axes with the given sizes, one of which may be
+// -1 to infer it from the size of the axis
+func (v *ViewStruct) Unflatten(axis int, sizes []int) (*ViewStruct, error) {
+	normalized, err := normalizeAxis(axis, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+	if len(sizes) == 0 {
+		return nil, fmt.Errorf("no sizes to unflatten axis %d into", axis)
+	}
+	sizes, err = inferShape(v.shape[normalized], sizes)
+	if err != nil {
+		return nil, err
+	}
+	if shapeSize(sizes) != v.shape[normalized] {
+		return nil, fmt.Errorf("sizes %v do not multiply to the size %d of axis %d", sizes, v.shape[normalized], axis)
+	}
+
+	// Splitting an axis never needs a copy
+	shape := append([]int{}, v.shape[:normalized]...)
+	shape = append(shape, sizes...)
+	shape = append(shape, v.shape[normalized+1:]...)
+	return v.ReshapeNoCopy(shape)
+}
+
+// expand broadcasts x to shape without copying, a -1 dimension keeps the size
+// of the matching axis of x
+func expand(x Operand, shape []int) (*BroadcastStruct, error) {
+	source := x.Shape()
+	expanded := append([]int{}, shape...)
+	if len(source) > len(shape) {
+		return nil, fmt.Errorf("cannot expand shape %v to lower rank shape %v", source, shape)
+	}
+	for axis, dim := range expanded {
+		sourceAxis := axis - (len(shape) - len(source))
+		switch {
+		case dim == -1 && sourceAxis < 0:
+			return nil, fmt.Errorf("cannot infer new leading axis %d of expanded shape %v", axis, shape)
+		case dim == -1:
+			expanded[axis] = source[sourceAxis]
+		case dim < 0:
+			return nil, fmt.Errorf("invalid dimension %d at axis %d", dim, axis)
+		case sourceAxis >= 0 && source[sourceAxis] != dim && source[sourceAxis] != 1:
+			return nil, fmt.Errorf("cannot expand axis %d of size %d to %d", axis, source[sourceAxis], dim)
+		}
+	}
+	return NewBroadcast(expanded, x)
+}
+
+// Expand returns a broadcast of the view to shape sharing the view's data, a
+// -1 dimension keeps the size of the matching axis
+func (v *ViewStruct) Expand(shape []int) (*BroadcastStruct, error) {
+	return expand(v, shape)
+}
+
+// Squeeze returns a view of the tensor without the given axes, which must
+// have size 1, without axes every axis of size 1 is removed
+func (t *TensorStruct) Squeeze(axes ...int) (*ViewStruct, error) {
+	return NewView(t).Squeeze(axes...)
+}
+
+// Unsqueeze returns a view of the tensor with a new axis of size 1 inserted at axis
+func (t *TensorStruct) Unsqueeze(axis int) (*ViewStruct, error) {
+	return NewView(t).Unsqueeze(axis)
+}
+
+// Flatten returns a view of the tensor with the axes from start to end
+// inclusive merged into one axis
+func (t *TensorStruct) Flatten(start, end int) (*ViewStruct, error) {
+	return NewView(t).Flatten(start, end)
+}
+
+// Unflatten returns a view of the tensor with axis split into axes with the given sizes
+func (t *TensorStruct) Unflatten(axis int, sizes []int) (*ViewStruct, error) {
+	return NewView(t).Unflatten(axis, sizes)
+}
+
+// Expand returns a broadcast of the tensor to shape sharing the tensor's data,
+// a -1 dimension keeps the size of the matching axis
+func (t *TensorStruct) Expand(shape []int) (*BroadcastStruct, error) {
+	return expand(t, shape)
+}
@@ -0,0 +1,110 @@
+package tensor
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestShapeOps tests squeezing, unsqueezing, flattening and unflattening
+func TestShapeOps(t *testing.T) {
+	data := []float64{1, 2, 3, 4, 5, 6}
+	x := mustNewTensor(t, []int{1, 2, 1, 3}, data)
+
+	testCases := []struct {
+		name          string
+		apply         func() (*ViewStruct, error)
+		expectedShape []int
+		expectErr     string
+	}{
+		{"ReshapeInferred", func() (*ViewStruct, error) { return x.View([]int{3, -1}) }, []int{3, 2}, ""},
+		{"SqueezeAll", func() (*ViewStruct, error) { return x.Squeeze() }, []int{2, 3}, ""},
+		{"SqueezeAxis", func() (*ViewStruct, error) { return x.Squeeze(-2) }, []int{1, 2, 3}, ""},
+		{"SqueezeWrongSize", func() (*ViewStruct, error) { return x.Squeeze(0, 1) }, nil, "axis 1"},
+		{"SqueezeOutOfRange", func() (*ViewStruct, error) { return x.Squeeze(4) }, nil, "axis 4"},
+		{"Unsqueeze", func() (*ViewStruct, error) { return x.Unsqueeze(1) }, []int{1, 1, 2, 1, 3}, ""},
+		{"UnsqueezeEnd", func() (*ViewStruct, error) { return x.Unsqueeze(-1) }, []int{1, 2, 1, 3, 1}, ""},
+		{"UnsqueezeOutOfRange", func() (*ViewStruct, error) { return x.Unsqueeze(6) }, nil, "axis 6"},
+		{"Flatten", func() (*ViewStruct, error) { return x.Flatten(1, -1) }, []int{1, 6}, ""},
+		{"FlattenAll", func() (*ViewStruct, error) { return x.Flatten(0, -1) }, []int{6}, ""},
+		{"FlattenReversed", func() (*ViewStruct, error) { return x.Flatten(2, 1) }, nil, "axis 2"},
+		{"Unflatten", func() (*ViewStruct, error) { return x.Unflatten(3, []int{-1, 1}) }, []int{1, 2, 1, 3, 1}, ""},
+		{"UnflattenMismatch", func() (*ViewStruct, error) { return x.Unflatten(1, []int{3}) }, nil, "axis 1"},
+		{"InferMismatch", func() (*ViewStruct, error) { return x.View([]int{4, -1}) }, nil, "axis 1"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			view, err := tc.apply()
+			if tc.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectErr) {
+					t.Fatalf("Expected error naming %q, got %v", tc.expectErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "Shape", tc.expectedShape, view.Shape())
+			checkEqual(t, "Data", data, view.Data())
+		})
+	}
+}
+
+// TestShapeOpsStrided tests shape operations on a transposed view
+func TestShapeOpsStrided(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	transposed := x.Transpose()
+
+	// Flattening a transposed view copies its elements in view order
+	flat, err := transposed.Flatten(0, 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Flatten", []float64{1, 4, 2, 5, 3, 6}, flat.Data())
+
+	// Unsqueezing keeps sharing the tensor's data
+	column, _ := transposed.Unsqueeze(1)
+	checkEqual(t, "Unsqueeze Shape", []int{3, 1, 2}, column.Shape())
+	column.Set(10, 2, 0, 1)
+	checkEqual(t, "Shared Data", []float64{1, 2, 3, 4, 5, 10}, x.Data())
+}
+
+// TestExpand tests broadcasting a tensor as a view with Expand
+func TestExpand(t *testing.T) {
+	x := mustNewTensor(t, []int{3, 1}, []float64{1, 2, 3})
+
+	expanded, err := x.Expand([]int{2, -1, 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Shape", []int{2, 3, 4}, expanded.Shape())
+	checkEqual(t, "Stride", []int{0, 1, 0}, expanded.Stride())
+	value, _ := expanded.At(1, 2, 3)
+	checkEqual(t, "At", 3.0, value)
+
+	errorCases := []struct {
+		name  string
+		shape []int
+		axis  string
+	}{
+		{"LeadingInferred", []int{-1, 3, 4}, "axis 0"},
+		{"WrongSize", []int{2, 4}, "axis 0"},
+		{"NegativeDimension", []int{3, -2}, "axis 1"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := x.Expand(tc.shape)
+			if err == nil || !strings.Contains(err.Error(), tc.axis) {
+				t.Errorf("Expected error naming %q, got %v", tc.axis, err)
+			}
+		})
+	}
+
+	column, _ := x.Slice(All(), Index(0))
+	rows, err := column.Expand([]int{2, 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	tensor, _ := rows.ToTensor()
+	checkEqual(t, "View Expand", []float64{1, 2, 3, 1, 2, 3}, tensor.Data())
+}
@@ -0,0 +1,207 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// End can be used as a start or stop bound to slice through the end of an
+// axis in the direction of the step, like an omitted bound in NumPy
+const End = math.MaxInt
+
+// sliceKind identifies what a SliceSpec does to an axis
+type sliceKind int
+
+const (
+	sliceRange sliceKind = iota
+	sliceIndex
+	sliceNewAxis
+	sliceEllipsis
+)
+
+// SliceSpec describes how one axis is selected when slicing a tensor
+type SliceSpec struct {
+	kind  sliceKind
+	start int
+	stop  int
+	step  int
+}
+
+var (
+	// NewAxis inserts a new axis of size 1
+	NewAxis = SliceSpec{kind: sliceNewAxis}
+	// Ellipsis stands for as many full axes as needed to cover the remaining dimensions
+	Ellipsis = SliceSpec{kind: sliceEllipsis}
+)
+
+// Range selects the elements from start up to, but not including, stop,
+// negative bounds count from the end of the axis
+func Range(start, stop int) SliceSpec {
+	return Step(start, stop, 1)
+}
+
+// Step selects every step-th element from start up to, but not including,
+// stop, a negative step walks the axis backwards
+func Step(start, stop, step int) SliceSpec {
+	return SliceSpec{kind: sliceRange, start: start, stop: stop, step: step}
+}
+
+// All selects every element of an axis
+func All() SliceSpec {
+	return Step(0, End, 1)
+}
+
+// Index selects a single element of an axis and removes the axis, a negative
+// index counts from the end of the axis
+func Index(i int) SliceSpec {
+	return SliceSpec{kind: sliceIndex, start: i}
+}
+
+// clampBound normalizes a negative bound and clamps it to the axis, following
+// the rules of Python slices
+func clampBound(bound int, size int, step int) int {
+	// Count negative bounds from the end
+	if bound < 0 {
+		bound += size
+	}
+
+	// Clamp to the valid range for the step direction
+	if step > 0 {
+		return max(0, min(bound, size))
+	}
+	return max(-1, min(bound, size-1))
+}
+
+// bounds returns the first index and the number of elements a range spec
+// selects on an axis of the given size
+func (s SliceSpec) bounds(size int) (int, int, error) {
+	// Check the step
+	if s.step == 0 {
+		return 0, 0, fmt.Errorf("slice step cannot be zero")
+	}
+
+	// Resolve the start, End means the first element in the step direction
+	start := s.start
+	switch {
+	case start == End && s.step > 0:
+		start = size
+	case start == End:
+		start = size - 1
+	default:
+		start = clampBound(start, size, s.step)
+	}
+
+	// Resolve the stop, End means past the last element in the step direction
+	stop := s.stop
+	switch {
+	case stop == End && s.step > 0:
+		stop = size
+	case stop == End:
+		stop = -1
+	default:
+		stop = clampBound(stop, size, s.step)
+	}
+
+	// Calculate the number of selected elements
+	length := 0
+	if s.step > 0 && stop > start {
+		length = (stop - start + s.step - 1) / s.step
+	} else if s.step < 0 && start > stop {
+		length = (start - stop - s.step - 1) / -s.step
+	}
+
+	// Return the bounds
+	return start, length, nil
+}
+
+// expandEllipsis replaces an ellipsis in the specs with full slices so that
+// the specs cover every axis of the given rank
+func expandEllipsis(specs []SliceSpec, rank int) ([]SliceSpec, error) {
+	// Count the specs that consume an axis and find the ellipsis
+	consumed := 0
+	ellipsis := -1
+	for i, spec := range specs {
+		switch spec.kind {
+		case sliceEllipsis:
+			if ellipsis >= 0 {
+				return nil, fmt.Errorf("slice can only contain one ellipsis")
+			}
+			ellipsis = i
+		case sliceRange, sliceIndex:
+			consumed++
+		}
+	}
+
+	// Check that there are not too many specs
+	if consumed > rank {
+		return nil, fmt.Errorf("too many indices for rank %d: got %d", rank, consumed)
+	}
+
+	// Fill the missing axes at the ellipsis, or after the last spec
+	fill := make([]SliceSpec, rank-consumed)
+	for i := range fill {
+		fill[i] = All()
+	}
+	if ellipsis < 0 {
+		return append(append([]SliceSpec{}, specs...), fill...), nil
+	}
+	expanded := append([]SliceSpec{}, specs[:ellipsis]...)
+	expanded = append(expanded, fill...)
+	return append(expanded, specs[ellipsis+1:]...), nil
+}
+
+// Slice returns a view of part of the view, each spec selects from the next
+// axis, axes without a spec are kept whole, the returned view shares the
+// view's data
+func (v *ViewStruct) Slice(specs ...SliceSpec) (*ViewStruct, error) {
+	// Expand the ellipsis so every axis has a spec
+	specs, err := expandEllipsis(specs, len(v.shape))
+	if err != nil {
+		return nil, err
+	}
+
+	// Apply each spec to its axis
+	shape := []int{}
+	stride := []int{}
+	offset := v.offset
+	axis := 0
+	for _, spec := range specs {
+		switch spec.kind {
+		case sliceNewAxis:
+			// Insert an axis of size 1
+			shape = append(shape, 1)
+			stride = append(stride, 0)
+		case sliceIndex:
+			// Select a single element and drop the axis
+			i := spec.start
+			if i < 0 {
+				i += v.shape[axis]
+			}
+			if i < 0 || i >= v.shape[axis] {
+				return nil, fmt.Errorf("index %d out of bounds for axis %d with size %d", spec.start, axis, v.shape[axis])
+			}
+			offset += i * v.stride[axis]
+			axis++
+		case sliceRange:
+			// Select a range of elements
+			start, length, err := spec.bounds(v.shape[axis])
+			if err != nil {
+				return nil, fmt.Errorf("invalid slice for axis %d: %v", axis, err)
+			}
+			if length > 0 {
+				offset += start * v.stride[axis]
+			}
+			shape = append(shape, length)
+			stride = append(stride, v.stride[axis]*spec.step)
+			axis++
+		}
+	}
+
+	// Return the view
+	return &ViewStruct{
+		shape:  shape,
+		stride: stride,
+		offset: offset,
+		tensor: v.tensor,
+	}, nil
+}
@@ -0,0 +1,100 @@
+package tensor
+
+import (
+	"testing"
+)
+
+// viewValues reads every element of a view in row-major order
+func viewValues(t *testing.T, v *ViewStruct) []float64 {
+	values := make([]float64, shapeSize(v.Shape()))
+	for i := range values {
+		value, err := v.AtFlat(i)
+		if err != nil {
+			t.Fatalf("Failed to read element %d: %v", i, err)
+		}
+		values[i] = value
+	}
+	return values
+}
+
+// TestSlice tests slicing tensors into views
+func TestSlice(t *testing.T) {
+	// 3x4 matrix holding 0..11
+	data := make([]float64, 12)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	matrix := mustNewTensor(t, []int{3, 4}, data)
+
+	testCases := []struct {
+		name           string
+		specs          []SliceSpec
+		expectedShape  []int
+		expectedStride []int
+		expectedOffset int
+		expectedData   []float64
+		expectErr      bool
+	}{
+		{"NoSpecs", nil, []int{3, 4}, []int{4, 1}, 0, data, false},
+		{"RowRange", []SliceSpec{Range(1, 3)}, []int{2, 4}, []int{4, 1}, 4, []float64{4, 5, 6, 7, 8, 9, 10, 11}, false},
+		{"RangeAndStep", []SliceSpec{Range(1, 3), Step(0, End, 2)}, []int{2, 2}, []int{4, 2}, 4, []float64{4, 6, 8, 10}, false},
+		{"Index", []SliceSpec{Index(1)}, []int{4}, []int{1}, 4, []float64{4, 5, 6, 7}, false},
+		{"IndexColumn", []SliceSpec{All(), Index(-1)}, []int{3}, []int{4}, 3, []float64{3, 7, 11}, false},
+		{"NegativeBounds", []SliceSpec{Range(-2, End), Range(-3, -1)}, []int{2, 2}, []int{4, 1}, 5, []float64{5, 6, 9, 10}, false},
+		{"NegativeStep", []SliceSpec{Index(0), Step(End, End, -1)}, []int{4}, []int{-1}, 3, []float64{3, 2, 1, 0}, false},
+		{"NegativeStepBounds", []SliceSpec{Step(-1, 0, -1), Index(0)}, []int{2}, []int{-4}, 8, []float64{8, 4}, false},
+		{"NegativeStepTwo", []SliceSpec{Index(2), Step(End, End, -2)}, []int{2}, []int{-2}, 11, []float64{11, 9}, false},
+		{"ClampedStop", []SliceSpec{Range(1, 100)}, []int{2, 4}, []int{4, 1}, 4, []float64{4, 5, 6, 7, 8, 9, 10, 11}, false},
+		{"Empty", []SliceSpec{Range(2, 1)}, []int{0, 4}, []int{4, 1}, 0, []float64{}, false},
+		{"NewAxis", []SliceSpec{NewAxis, Index(0)}, []int{1, 4}, []int{0, 1}, 0, []float64{0, 1, 2, 3}, false},
+		{"NewAxisMiddle", []SliceSpec{Index(2), NewAxis, Range(0, 2)}, []int{1, 2}, []int{0, 1}, 8, []float64{8, 9}, false},
+		{"EllipsisFirst", []SliceSpec{Ellipsis, Index(1)}, []int{3}, []int{4}, 1, []float64{1, 5, 9}, false},
+		{"EllipsisLast", []SliceSpec{Index(1), Ellipsis}, []int{4}, []int{1}, 4, []float64{4, 5, 6, 7}, false},
+		{"EllipsisEmpty", []SliceSpec{Index(1), Ellipsis, Index(2)}, []int{}, []int{}, 6, []float64{6}, false},
+		{"TwoEllipses", []SliceSpec{Ellipsis, Ellipsis}, nil, nil, 0, nil, true},
+		{"TooManySpecs", []SliceSpec{Index(0), Index(0), Index(0)}, nil, nil, 0, nil, true},
+		{"IndexOutOfBounds", []SliceSpec{Index(3)}, nil, nil, 0, nil, true},
+		{"NegativeIndexOutOfBounds", []SliceSpec{All(), Index(-5)}, nil, nil, 0, nil, true},
+		{"ZeroStep", []SliceSpec{Step(0, 2, 0)}, nil, nil, 0, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			view, err := matrix.Slice(tc.specs...)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err != nil {
+				return
+			}
+			checkEqual(t, "Shape", tc.expectedShape, view.Shape())
+			checkEqual(t, "Stride", tc.expectedStride, view.Stride())
+			checkEqual(t, "Offset", tc.expectedOffset, view.Offset())
+			checkEqual(t, "Data", tc.expectedData, viewValues(t, view))
+		})
+	}
+}
+
+// TestSliceSharesData tests that slices write through to the tensor
+func TestSliceSharesData(t *testing.T) {
+	matrix := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	column, err := matrix.Slice(All(), Index(1))
+	if err != nil {
+		t.Fatalf("Failed to slice: %v", err)
+	}
+	if err := column.Set(20, 0); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+
+	// Slicing a slice composes the offsets
+	last, err := column.Slice(Index(-1))
+	if err != nil {
+		t.Fatalf("Failed to slice: %v", err)
+	}
+	if err := last.Set(50); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+
+	checkEqual(t, "Data", []float64{1, 20, 3, 4, 50, 6}, matrix.Data())
+}
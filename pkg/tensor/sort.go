@@ -0,0 +1,388 @@
+package tensor
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+)
+
+// elementAccess returns the functions reading and writing the elements of s
+// as T, int64 access keeps integer elements exact
+func elementAccess[T float64 | int64](s storage) (func(i int) T, func(i int, v T)) {
+	var zero T
+	if _, ok := any(zero).(int64); ok {
+		return any(s.getInt).(func(int) T), any(s.setInt).(func(int, T))
+	}
+	return any(s.get).(func(int) T), any(s.set).(func(int, T))
+}
+
+// mapLanes calls fn with the elements of every lane of x along axis, fn fills
+// the lane of each output, the outputs have the shape of x with the axis
+// resized to n and the given dtypes
+func mapLanes[T float64 | int64](x Operand, axis int, n int, dtypes []DType, fn func(values []T, outputs [][]T)) []*TensorStruct {
+	shape, stride := x.Shape(), x.Stride()
+	get, _ := elementAccess[T](x.storage())
+	length := shape[axis]
+
+	// Initialize the outputs
+	outShape := append([]int{}, shape...)
+	outShape[axis] = n
+	outStride := computeStrides(outShape)
+	results := make([]storage, len(dtypes))
+	setters := make([]func(int, T), len(dtypes))
+	for i, dtype := range dtypes {
+		results[i] = newStorage(dtype, shapeSize(outShape))
+		_, setters[i] = elementAccess[T](results[i])
+	}
+
+	// Walk the lanes, which are the positions of x with the axis fixed at 0
+	laneShape := append([]int{}, shape...)
+	laneShape[axis] = 1
+	parallelFor(shapeSize(laneShape), length+n, func(start, end int) {
+		values := make([]T, length)
+		outputs := make([][]T, len(dtypes))
+		for i := range outputs {
+			outputs[i] = make([]T, n)
+		}
+		lane := unravelIndex(start, laneShape)
+		for l := start; l < end; l++ {
+			// Read the lane
+			offset := offsetAt(x, stride, lane)
+			for i := range values {
+				values[i] = get(offset + i*stride[axis])
+			}
+
+			// Compute and write the outputs
+			fn(values, outputs)
+			outOffset := 0
+			for j, v := range lane {
+				outOffset += v * outStride[j]
+			}
+			for i, output := range outputs {
+				for j, v := range output {
+					setters[i](outOffset+j*outStride[axis], v)
+				}
+			}
+			nextIndex(lane, laneShape)
+		}
+	})
+
+	// Return the outputs
+	tensors := make([]*TensorStruct, len(results))
+	for i, result := range results {
+		tensors[i] = newTensor(outShape, result)
+	}
+	return tensors
+}
+
+// compareValues orders a before b, NaN is placed after every number
+func compareValues(a, b float64) int {
+	switch {
+	case math.IsNaN(a) && math.IsNaN(b):
+		return 0
+	case math.IsNaN(a):
+		return 1
+	case math.IsNaN(b):
+		return -1
+	default:
+		return cmp.Compare(a, b)
+	}
+}
+
+// sortedOrder fills order with the indices of values in sorted order, equal
+// values keep the order they appear in
+func sortedOrder(values []float64, order []int, descending bool) {
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		if descending {
+			return compareValues(values[j], values[i])
+		}
+		return compareValues(values[i], values[j])
+	})
+}
+
+// sortAxis sorts x along axis, returning the sorted values and their Int64
+// indices into x, NaN counts as larger than any number
+func sortAxis(x Operand, axis int, descending bool) (*TensorStruct, *TensorStruct, error) {
+	if len(x.Shape()) == 0 {
+		return nil, nil, fmt.Errorf("cannot sort a scalar")
+	}
+	normalized, err := normalizeAxis(axis, len(x.Shape()))
+	if err != nil {
+		return nil, nil, err
+	}
+	n := x.Shape()[normalized]
+	results := mapLanes(x, normalized, n, []DType{x.DType(), Int64}, func(values []float64, outputs [][]float64) {
+		order := make([]int, len(values))
+		sortedOrder(values, order, descending)
+		for i, j := range order {
+			outputs[0][i] = values[j]
+			outputs[1][i] = float64(j)
+		}
+	})
+	return results[0], results[1], nil
+}
+
+// argSort returns the Int64 indices that sort x along axis
+func argSort(x Operand, axis int, descending bool) (*TensorStruct, error) {
+	_, indices, err := sortAxis(x, axis, descending)
+	return indices, err
+}
+
+// topK returns the k largest values of x along axis in descending order and
+// their Int64 indices, equal values are taken in the order they appear
+func topK(x Operand, k int, axis int) (*TensorStruct, *TensorStruct, error) {
+	if len(x.Shape()) == 0 {
+		return nil, nil, fmt.Errorf("cannot take the top k of a scalar")
+	}
+	normalized, err := normalizeAxis(axis, len(x.Shape()))
+	if err != nil {
+		return nil, nil, err
+	}
+	if k < 0 || k > x.Shape()[normalized] {
+		return nil, nil, fmt.Errorf("k %d out of range for axis %d with size %d", k, axis, x.Shape()[normalized])
+	}
+	results := mapLanes(x, normalized, k, []DType{x.DType(), Int64}, func(values []float64, outputs [][]float64) {
+		order := make([]int, len(values))
+		sortedOrder(values, order, true)
+		for i, j := range order[:k] {
+			outputs[0][i] = values[j]
+			outputs[1][i] = float64(j)
+		}
+	})
+	return results[0], results[1], nil
+}
+
+// unique returns the sorted unique elements of x, how often each occurs as
+// Int64 counts and the Int64 index into the unique elements of every element
+// of x, all NaN elements count as one value
+func unique(x Operand) (*TensorStruct, *TensorStruct, *TensorStruct) {
+	values := toFloat64s(contiguousStorage(x))
+	order := make([]int, len(values))
+	sortedOrder(values, order, false)
+
+	// Walk the sorted elements, starting a new unique value at each change
+	uniqueValues, counts := []float64{}, []float64{}
+	inverse := newStorage(Int64, len(values))
+	for _, j := range order {
+		last := len(uniqueValues) - 1
+		if last < 0 || compareValues(uniqueValues[last], values[j]) != 0 {
+			uniqueValues = append(uniqueValues, values[j])
+			counts = append(counts, 0)
+			last++
+		}
+		counts[last]++
+		inverse.set(j, float64(last))
+	}
+
+	// Store the unique values with the dtype of x
+	uniques := newStorage(x.DType(), len(uniqueValues))
+	for i, v := range uniqueValues {
+		uniques.set(i, v)
+	}
+	countData := newStorage(Int64, len(counts))
+	for i, c := range counts {
+		countData.set(i, c)
+	}
+	return newTensor([]int{len(uniqueValues)}, uniques), newTensor([]int{len(counts)}, countData), newTensor(append([]int{}, x.Shape()...), inverse)
+}
+
+// searchSorted returns the Int64 indices where each element of values would be
+// inserted into the sorted 1D x to keep it sorted, with right an element is
+// placed after equal elements of x instead of before them
+func searchSorted(x Operand, values Operand, right bool) (*TensorStruct, error) {
+	if len(x.Shape()) != 1 {
+		return nil, fmt.Errorf("search sorted requires a 1D tensor, got %dD", len(x.Shape()))
+	}
+	sorted := toFloat64s(contiguousStorage(x))
+	return unary(values, Int64, func(v float64) float64 {
+		return float64(sort.Search(len(sorted), func(i int) bool {
+			if right {
+				return compareValues(sorted[i], v) > 0
+			}
+			return compareValues(sorted[i], v) >= 0
+		}))
+	}), nil
+}
+
+// quantileValues returns the q-th quantile of the values, interpolating
+// linearly between the two nearest values, NaN if any value is NaN
+func quantileValues(values []float64, q float64) float64 {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, compareValues)
+	if math.IsNaN(sorted[len(sorted)-1]) {
+		return math.NaN()
+	}
+	position := q * float64(len(sorted)-1)
+	lower := int(math.Floor(position))
+	upper := min(lower+1, len(sorted)-1)
+	return sorted[lower] + (position-float64(lower))*(sorted[upper]-sorted[lower])
+}
+
+// quantile reduces x to its q-th quantiles over the axes
+func quantile(x Operand, q float64, axes []int, keepDims bool) (*TensorStruct, error) {
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return nil, fmt.Errorf("quantile %v out of range [0, 1]", q)
+	}
+	return reduceNonEmpty("quantile", x, axes, keepDims, floatType(x.DType()), func(values []float64) float64 {
+		return quantileValues(values, q)
+	})
+}
+
+// median reduces x to its medians over the axes
+func median(x Operand, axes []int, keepDims bool) (*TensorStruct, error) {
+	return reduceNonEmpty("median", x, axes, keepDims, floatType(x.DType()), func(values []float64) float64 {
+		return quantileValues(values, 0.5)
+	})
+}
+
+// histogram counts the elements of x in bins of equal width spanning the
+// smallest to the largest element, returning the Int64 counts and the bins+1
+// bin edges, the last bin includes its right edge
+func histogram(x Operand, bins int) (*TensorStruct, *TensorStruct, error) {
+	if bins < 1 {
+		return nil, nil, fmt.Errorf("invalid number of bins: %d", bins)
+	}
+
+	// Find the range of the elements, widening an empty range
+	values := toFloat64s(contiguousStorage(x))
+	low, high := 0.0, 1.0
+	if len(values) > 0 {
+		low, high = math.Inf(1), math.Inf(-1)
+		for _, v := range values {
+			if math.IsNaN(v) || math.IsInf(v, 0) {
+				return nil, nil, fmt.Errorf("cannot compute histogram of non-finite value %v", v)
+			}
+			low, high = math.Min(low, v), math.Max(high, v)
+		}
+	}
+	if low == high {
+		low, high = low-0.5, high+0.5
+	}
+
+	// Compute the edges, halving the bounds when their difference overflows
+	span := high - low
+	halved := math.IsInf(span, 0)
+	if halved {
+		span = high/2 - low/2
+	}
+	edges := newStorage(Float64, bins+1)
+	for i := 0; i < bins; i++ {
+		offset := span * (float64(i) / float64(bins))
+		if halved {
+			edges.set(i, low+offset+offset)
+		} else {
+			edges.set(i, low+offset)
+		}
+	}
+	edges.set(bins, high)
+
+	// Count the elements of each bin, scaling each element to a fraction of
+	// the range so a tiny width cannot underflow, bounds too close to widen
+	// give no fraction and count in the middle bin
+	counts := newStorage(Int64, bins)
+	for _, v := range values {
+		fraction := (v - low) / span
+		if halved {
+			fraction = (v/2 - low/2) / span
+		}
+		bin := bins / 2
+		if position := fraction * float64(bins); !math.IsNaN(position) {
+			bin = int(min(max(position, 0), float64(bins-1)))
+		}
+		counts.setInt(bin, counts.getInt(bin)+1)
+	}
+	return newTensor([]int{bins}, counts), newTensor([]int{bins + 1}, edges), nil
+}
+
+// Sort returns the tensor sorted along axis and the Int64 indices of the
+// sorted elements, the sort is stable and NaN counts as larger than any number
+func (t *TensorStruct) Sort(axis int, descending bool) (*TensorStruct, *TensorStruct, error) {
+	return sortAxis(t, axis, descending)
+}
+
+// ArgSort returns the Int64 indices that stably sort the tensor along axis
+func (t *TensorStruct) ArgSort(axis int, descending bool) (*TensorStruct, error) {
+	return argSort(t, axis, descending)
+}
+
+// TopK returns the k largest elements of the tensor along axis in descending
+// order and their Int64 indices
+func (t *TensorStruct) TopK(k int, axis int) (*TensorStruct, *TensorStruct, error) {
+	return topK(t, k, axis)
+}
+
+// Unique returns the sorted unique elements of the tensor, their counts and
+// the index into the unique elements of every element of the tensor
+func (t *TensorStruct) Unique() (*TensorStruct, *TensorStruct, *TensorStruct) {
+	return unique(t)
+}
+
+// SearchSorted returns the indices where values would be inserted into the
+// sorted 1D tensor to keep it sorted
+func (t *TensorStruct) SearchSorted(values Operand, right bool) (*TensorStruct, error) {
+	return searchSorted(t, values, right)
+}
+
+// Median reduces the tensor to its medians over the axes
+func (t *TensorStruct) Median(axes []int, keepDims bool) (*TensorStruct, error) {
+	return median(t, axes, keepDims)
+}
+
+// Quantile reduces the tensor to its q-th quantiles over the axes
+func (t *TensorStruct) Quantile(q float64, axes []int, keepDims bool) (*TensorStruct, error) {
+	return quantile(t, q, axes, keepDims)
+}
+
+// Histogram counts the elements of the tensor in bins of equal width
+func (t *TensorStruct) Histogram(bins int) (*TensorStruct, *TensorStruct, error) {
+	return histogram(t, bins)
+}
+
+// Sort returns the view sorted along axis and the Int64 indices of the sorted
+// elements, the sort is stable and NaN counts as larger than any number
+func (v *ViewStruct) Sort(axis int, descending bool) (*TensorStruct, *TensorStruct, error) {
+	return sortAxis(v, axis, descending)
+}
+
+// ArgSort returns the Int64 indices that stably sort the view along axis
+func (v *ViewStruct) ArgSort(axis int, descending bool) (*TensorStruct, error) {
+	return argSort(v, axis, descending)
+}
+
+// TopK returns the k largest elements of the view along axis in descending
+// order and their Int64 indices
+func (v *ViewStruct) TopK(k int, axis int) (*TensorStruct, *TensorStruct, error) {
+	return topK(v, k, axis)
+}
+
+// Unique returns the sorted unique elements of the view, their counts and
+// the index into the unique elements of every element of the view
+func (v *ViewStruct) Unique() (*TensorStruct, *TensorStruct, *TensorStruct) {
+	return unique(v)
+}
+
+// SearchSorted returns the indices where values would be inserted into the
+// sorted 1D view to keep it sorted
+func (v *ViewStruct) SearchSorted(values Operand, right bool) (*TensorStruct, error) {
+	return searchSorted(v, values, right)
+}
+
+// Median reduces the view to its medians over the axes
+func (v *ViewStruct) Median(axes []int, keepDims bool) (*TensorStruct, error) {
+	return median(v, axes, keepDims)
+}
+
+// Quantile reduces the view to its q-th quantiles over the axes
+func (v *ViewStruct) Quantile(q float64, axes []int, keepDims bool) (*TensorStruct, error) {
+	return quantile(v, q, axes, keepDims)
+}
+
+// Histogram counts the elements of the view in bins of equal width
+func (v *ViewStruct) Histogram(bins int) (*TensorStruct, *TensorStruct, error) {
+	return histogram(v, bins)
+}
@@ -0,0 +1,206 @@
+package tensor
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestSort tests sorting along an axis and the returned indices
+func TestSort(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 4}, []float64{3, 1, 2, 1, 5, math.NaN(), 4, 5})
+
+	testCases := []struct {
+		name            string
+		apply           func() (*TensorStruct, *TensorStruct, error)
+		expectedShape   []int
+		expectedValues  []float64
+		expectedIndices []float64
+	}{
+		{"Ascending", func() (*TensorStruct, *TensorStruct, error) { return x.Sort(1, false) },
+			[]int{2, 4}, []float64{1, 1, 2, 3, 4, 5, 5, math.NaN()}, []float64{1, 3, 2, 0, 2, 0, 3, 1}},
+		{"Descending", func() (*TensorStruct, *TensorStruct, error) { return x.Sort(-1, true) },
+			[]int{2, 4}, []float64{3, 2, 1, 1, math.NaN(), 5, 5, 4}, []float64{0, 2, 1, 3, 1, 0, 3, 2}},
+		{"Columns", func() (*TensorStruct, *TensorStruct, error) { return x.Sort(0, false) },
+			[]int{2, 4}, []float64{3, 1, 2, 1, 5, math.NaN(), 4, 5}, []float64{0, 0, 0, 0, 1, 1, 1, 1}},
+		{"View", func() (*TensorStruct, *TensorStruct, error) { return x.Transpose().Sort(0, true) },
+			[]int{4, 2}, []float64{3, math.NaN(), 2, 5, 1, 5, 1, 4}, []float64{0, 1, 2, 0, 1, 3, 3, 2}},
+		{"TopK", func() (*TensorStruct, *TensorStruct, error) { return x.TopK(2, 1) },
+			[]int{2, 2}, []float64{3, 2, math.NaN(), 5}, []float64{0, 2, 1, 0}},
+		{"TopKZero", func() (*TensorStruct, *TensorStruct, error) { return x.TopK(0, 1) },
+			[]int{2, 0}, []float64{}, []float64{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			values, indices, err := tc.apply()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "Shape", tc.expectedShape, values.Shape())
+			checkEqual(t, "Indices DType", Int64, indices.DType())
+			checkEqual(t, "Indices", tc.expectedIndices, indices.Data())
+			if !almostEqual(tc.expectedValues, values.Data()) {
+				t.Errorf("Expected values %v, got %v", tc.expectedValues, values.Data())
+			}
+		})
+	}
+
+	// ArgSort returns the indices of Sort
+	indices, err := x.ArgSort(1, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "ArgSort", []float64{1, 3, 2, 0, 2, 0, 3, 1}, indices.Data())
+}
+
+// TestUnique tests the unique values, counts and inverse indices
+func TestUnique(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{3, 1, math.NaN(), 1, math.NaN(), 3})
+
+	values, counts, inverse := x.Unique()
+	checkEqual(t, "Values Shape", []int{3}, values.Shape())
+	if !almostEqual([]float64{1, 3, math.NaN()}, values.Data()) {
+		t.Errorf("Expected values %v, got %v", []float64{1, 3, math.NaN()}, values.Data())
+	}
+	checkEqual(t, "Counts", []float64{2, 2, 2}, counts.Data())
+	checkEqual(t, "Inverse Shape", []int{2, 3}, inverse.Shape())
+	checkEqual(t, "Inverse", []float64{1, 0, 2, 0, 2, 1}, inverse.Data())
+
+	// Unique keeps the dtype
+	ints, _ := NewTensorOf([]int{4}, []int32{2, 2, 2, 0})
+	values, counts, _ = ints.Unique()
+	checkEqual(t, "DType", Int32, values.DType())
+	checkEqual(t, "Int Values", []float64{0, 2}, values.Data())
+	checkEqual(t, "Int Counts", []float64{1, 3}, counts.Data())
+}
+
+// TestSearchSorted tests finding insertion points in a sorted tensor
+func TestSearchSorted(t *testing.T) {
+	sorted := mustNewTensor(t, []int{5}, []float64{1, 2, 2, 3, 5})
+	values := mustNewTensor(t, []int{2, 3}, []float64{0, 2, 4, 5, 6, 2.5})
+
+	left, err := sorted.SearchSorted(values, false)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Shape", []int{2, 3}, left.Shape())
+	checkEqual(t, "Left", []float64{0, 1, 4, 4, 5, 3}, left.Data())
+
+	right, err := sorted.SearchSorted(values, true)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Right", []float64{0, 3, 4, 5, 5, 3}, right.Data())
+}
+
+// TestQuantiles tests medians and interpolated quantiles
+func TestQuantiles(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 4}, []float64{4, 1, 3, 2, 10, 30, 20, 40})
+	odd, _ := x.Slice(All(), Range(0, 3))
+
+	testCases := []struct {
+		name          string
+		apply         func() (*TensorStruct, error)
+		expectedShape []int
+		expectedData  []float64
+	}{
+		{"Median", func() (*TensorStruct, error) { return x.Median(nil, false) }, []int{}, []float64{7}},
+		{"MedianRows", func() (*TensorStruct, error) { return x.Median([]int{1}, false) }, []int{2}, []float64{2.5, 25}},
+		{"MedianOdd", func() (*TensorStruct, error) { return odd.Median([]int{1}, true) }, []int{2, 1}, []float64{3, 20}},
+		{"QuantileMin", func() (*TensorStruct, error) { return x.Quantile(0, []int{1}, false) }, []int{2}, []float64{1, 10}},
+		{"QuantileMax", func() (*TensorStruct, error) { return x.Quantile(1, []int{1}, false) }, []int{2}, []float64{4, 40}},
+		{"QuantileInterpolated", func() (*TensorStruct, error) { return x.Quantile(0.25, []int{1}, false) }, []int{2}, []float64{1.75, 17.5}},
+		{"QuantileColumns", func() (*TensorStruct, error) { return x.Quantile(0.5, []int{0}, false) }, []int{4}, []float64{7, 15.5, 11.5, 21}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := tc.apply()
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			checkEqual(t, "Shape", tc.expectedShape, result.Shape())
+			if !almostEqual(tc.expectedData, result.Data()) {
+				t.Errorf("Expected %v, got %v", tc.expectedData, result.Data())
+			}
+		})
+	}
+
+	// A NaN makes the median NaN
+	nan, _ := mustNewTensor(t, []int{3}, []float64{1, math.NaN(), 2}).Median(nil, false)
+	if !math.IsNaN(nan.Data()[0]) {
+		t.Errorf("Expected NaN median, got %v", nan.Data())
+	}
+}
+
+// TestHistogram tests counting elements in bins of equal width
+func TestHistogram(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{0, 1, 1, 2, 3, 4})
+
+	counts, edges, err := x.Histogram(4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Counts DType", Int64, counts.DType())
+	checkEqual(t, "Counts", []float64{1, 2, 1, 2}, counts.Data())
+	checkEqual(t, "Edges", []float64{0, 1, 2, 3, 4}, edges.Data())
+
+	// A single value is centered in a bin of width 1
+	counts, edges, err = NewScalar(5).Histogram(2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Single Counts", []float64{0, 1}, counts.Data())
+	checkEqual(t, "Single Edges", []float64{4.5, 5, 5.5}, edges.Data())
+
+	// A width that underflows or a range that overflows still finds the bins
+	tiny := mustNewTensor(t, []int{2}, []float64{0, 5e-324})
+	counts, _, err = tiny.Histogram(10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Tiny Counts", []float64{1, 0, 0, 0, 0, 0, 0, 0, 0, 1}, counts.Data())
+
+	wide := mustNewTensor(t, []int{3}, []float64{-math.MaxFloat64, 0, math.MaxFloat64})
+	counts, edges, err = wide.Histogram(4)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Wide Counts", []float64{1, 0, 1, 1}, counts.Data())
+	for i, edge := range edges.Data() {
+		if math.IsInf(edge, 0) || math.IsNaN(edge) || (i > 0 && edge <= edges.Data()[i-1]) {
+			t.Errorf("Expected finite increasing edges, got %v", edges.Data())
+		}
+	}
+}
+
+// TestSortErrors tests that invalid arguments are reported
+func TestSortErrors(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	empty, _ := Zeros([]int{0})
+
+	testCases := []struct {
+		name     string
+		apply    func() error
+		expected string
+	}{
+		{"SortAxis", func() error { _, _, err := x.Sort(2, false); return err }, "out of range"},
+		{"SortScalar", func() error { _, _, err := NewScalar(1).Sort(0, false); return err }, "scalar"},
+		{"TopKTooLarge", func() error { _, _, err := x.TopK(4, 1); return err }, "k 4 out of range"},
+		{"SearchSorted2D", func() error { _, err := x.SearchSorted(x, false); return err }, "1D"},
+		{"Quantile", func() error { _, err := x.Quantile(1.5, nil, false); return err }, "out of range"},
+		{"MedianEmpty", func() error { _, err := empty.Median(nil, false); return err }, "empty"},
+		{"HistogramBins", func() error { _, _, err := x.Histogram(0); return err }, "bins"},
+		{"HistogramNaN", func() error { _, _, err := NewScalar(math.NaN()).Histogram(1); return err }, "non-finite"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.apply()
+			if err == nil || !strings.Contains(err.Error(), tc.expected) {
+				t.Errorf("Expected error containing %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+package tensor
+
+import (
+	"reflect"
+)
+
+// storage is the typed buffer holding the elements of a tensor, elements are
+// read and written as float64 regardless of how they are stored, or as int64
+// by getInt and setInt so integer elements beyond 2^53 stay exact
+type storage interface {
+	dtype() DType
+	len() int
+	get(i int) float64
+	set(i int, v float64)
+	getInt(i int) int64
+	setInt(i int, v int64)
+	slice(start, end int) storage
+}
+
+// float64Storage stores float64 elements
+type float64Storage []float64
+
+func (s float64Storage) dtype() DType                 { return Float64 }
+func (s float64Storage) len() int                     { return len(s) }
+func (s float64Storage) get(i int) float64            { return s[i] }
+func (s float64Storage) set(i int, v float64)         { s[i] = v }
+func (s float64Storage) getInt(i int) int64           { return int64(s[i]) }
+func (s float64Storage) setInt(i int, v int64)        { s[i] = float64(v) }
+func (s float64Storage) slice(start, end int) storage { return s[start:end] }
+
+// float32Storage stores float32 elements
+type float32Storage []float32
+
+func (s float32Storage) dtype() DType                 { return Float32 }
+func (s float32Storage) len() int                     { return len(s) }
+func (s float32Storage) get(i int) float64            { return float64(s[i]) }
+func (s float32Storage) set(i int, v float64)         { s[i] = float32(v) }
+func (s float32Storage) getInt(i int) int64           { return int64(s[i]) }
+func (s float32Storage) setInt(i int, v int64)        { s[i] = float32(v) }
+func (s float32Storage) slice(start, end int) storage { return s[start:end] }
+
+// int64Storage stores int64 elements
+type int64Storage []int64
+
+func (s int64Storage) dtype() DType                 { return Int64 }
+func (s int64Storage) len() int                     { return len(s) }
+func (s int64Storage) get(i int) float64            { return float64(s[i]) }
+func (s int64Storage) set(i int, v float64)         { s[i] = int64(v) }
+func (s int64Storage) getInt(i int) int64           { return s[i] }
+func (s int64Storage) setInt(i int, v int64)        { s[i] = v }
+func (s int64Storage) slice(start, end int) storage { return s[start:end] }
+
+// int32Storage stores int32 elements
+type int32Storage []int32
+
+func (s int32Storage) dtype() DType                 { return Int32 }
+func (s int32Storage) len() int                     { return len(s) }
+func (s int32Storage) get(i int) float64            { return float64(s[i]) }
+func (s int32Storage) set(i int, v float64)         { s[i] = int32(int64(v)) }
+func (s int32Storage) getInt(i int) int64           { return int64(s[i]) }
+func (s int32Storage) setInt(i int, v int64)        { s[i] = int32(v) }
+func (s int32Storage) slice(start, end int) storage { return s[start:end] }
+
+// uint8Storage stores uint8 elements
+type uint8Storage []uint8
+
+func (s uint8Storage) dtype() DType                 { return Uint8 }
+func (s uint8Storage) len() int                     { return len(s) }
+func (s uint8Storage) get(i int) float64            { return float64(s[i]) }
+func (s uint8Storage) set(i int, v float64)         { s[i] = uint8(int64(v)) }
+func (s uint8Storage) getInt(i int) int64           { return int64(s[i]) }
+func (s uint8Storage) setInt(i int, v int64)        { s[i] = uint8(v) }
+func (s uint8Storage) slice(start, end int) storage { return s[start:end] }
+
+// boolStorage stores bool elements, reading true as 1 and writing any non-zero value as true
+type boolStorage []bool
+
+func (s boolStorage) dtype() DType { return Bool }
+func (s boolStorage) len() int     { return len(s) }
+func (s boolStorage) get(i int) float64 {
+	if s[i] {
+		return 1
+	}
+	return 0
+}
+func (s boolStorage) set(i int, v float64) { s[i] = v != 0 }
+func (s boolStorage) getInt(i int) int64 {
+	if s[i] {
+		return 1
+	}
+	return 0
+}
+func (s boolStorage) setInt(i int, v int64)        { s[i] = v != 0 }
+func (s boolStorage) slice(start, end int) storage { return s[start:end] }
+
+// newStorage allocates zeroed storage for n elements of the given dtype
+func newStorage(dtype DType, n int) storage {
+	switch dtype {
+	case Float64:
+		return float64Storage(allocFloat64s(n))
+	case Float32:
+		return make(float32Storage, n)
+	case Int64:
+		return make(int64Storage, n)
+	case Int32:
+		return make(int32Storage, n)
+	case Uint8:
+		return make(uint8Storage, n)
+	case Bool:
+		return make(boolStorage, n)
+	default:
+		panic("tensor: unsupported dtype " + dtype.String())
+	}
+}
+
+// storageOf wraps a slice of elements as storage without copying it
+func storageOf[T Element](data []T) storage {
+	switch d := any(data).(type) {
+	case []float64:
+		return float64Storage(d)
+	case []float32:
+		return float32Storage(d)
+	case []int64:
+		return int64Storage(d)
+	case []int32:
+		return int32Storage(d)
+	case []uint8:
+		return uint8Storage(d)
+	default:
+		return boolStorage(any(data).([]bool))
+	}
+}
+
+// isExact reports whether elements of the dtypes can be combined as int64
+// values, which holds when none of them is a floating point dtype
+func isExact(dtypes ...DType) bool {
+	for _, dtype := range dtypes {
+		if dtype.IsFloat() {
+			return false
+		}
+	}
+	return true
+}
+
+// copyElement copies element j of src into element i of dst, integer elements
+// are copied as int64 so they stay exact
+func copyElement(dst storage, i int, src storage, j int) {
+	if isExact(dst.dtype(), src.dtype()) {
+		dst.setInt(i, src.getInt(j))
+	} else {
+		dst.set(i, src.get(j))
+	}
+}
+
+// toFloat64s returns the elements of the storage as float64 values, float64
+// storage is returned as is, any other dtype is converted into a new slice
+func toFloat64s(s storage) []float64 {
+	// Return float64 storage directly
+	if f, ok := s.(float64Storage); ok {
+		return f
+	}
+
+	// Convert every element
+	values := make([]float64, s.len())
+	for i := range values {
+		values[i] = s.get(i)
+	}
+	return values
+}
+
+// rawSlice returns the Go slice backing the storage
+func rawSlice(s storage) any {
+	switch d := s.(type) {
+	case float64Storage:
+		return []float64(d)
+	case float32Storage:
+		return []float32(d)
+	case int64Storage:
+		return []int64(d)
+	case int32Storage:
+		return []int32(d)
+	case uint8Storage:
+		return []uint8(d)
+	case boolStorage:
+		return []bool(d)
+	default:
+		return nil
+	}
+}
+
+// overlaps reports whether the storages share any memory
+func overlaps(a storage, b storage) bool {
+	if a.dtype() != b.dtype() || a.len() == 0 || b.len() == 0 {
+		return false
+	}
+	aSlice, bSlice := reflect.ValueOf(rawSlice(a)), reflect.ValueOf(rawSlice(b))
+	size := aSlice.Type().Elem().Size()
+	aStart, bStart := aSlice.Pointer(), bSlice.Pointer()
+	return aStart < bStart+uintptr(b.len())*size && bStart < aStart+uintptr(a.len())*size
+}
+
+// gather copies the elements of data laid out with the given shape, strides
+// and offset into new packed storage in row-major order
+func gather(data storage, shape []int, stride []int, offset int) storage {
+	// Initialize the packed storage
+	packed := newStorage(data.dtype(), shapeSize(shape))
+
+	// Copy every element through the strides
+	idx := make([]int, len(shape))
+	for i := 0; i < packed.len(); i++ {
+		src := offset
+		for j, v := range idx {
+			src += v * stride[j]
+		}
+		copyElement(packed, i, data, src)
+		nextIndex(idx, shape)
+	}
+
+	// Return the packed storage
+	return packed
+}
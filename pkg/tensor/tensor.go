@@ -2,7 +2,6 @@ package tensor
 
 import (
 	"fmt"
-	"reflect"
 	"strings"
 )
 
@@ -10,7 +9,7 @@ import (
 type TensorStruct struct {
 	shape  []int
 	stride []int
-	data   []float64
+	data   storage
 }
 
 // computeStrides computes the stride of a tensor given its shape
@@ -35,25 +34,135 @@ func computeStrides(shape []int) []int {
 
 // Tensor is the interface for a tensor
 type Tensor interface {
-	Shape() []int
+	Operand
+
 	Rank() int
-	Stride() []int
 	Data() []float64
 
 	String() string
 
+	At(idx ...int) (float64, error)
+	Set(value float64, idx ...int) error
+	AtFlat(idx int) (float64, error)
+	SetFlat(value float64, idx int) error
+	Item() (float64, error)
+
+	AsType(dtype DType) (*TensorStruct, error)
 	View(shape []int) (*ViewStruct, error)
+	Slice(specs ...SliceSpec) (*ViewStruct, error)
+	Transpose() *ViewStruct
+	Permute(axes ...int) (*ViewStruct, error)
+	SwapAxes(a, b int) (*ViewStruct, error)
+	MoveAxis(src, dst int) (*ViewStruct, error)
+	Squeeze(axes ...int) (*ViewStruct, error)
+	Unsqueeze(axis int) (*ViewStruct, error)
+	Flatten(start, end int) (*ViewStruct, error)
+	Unflatten(axis int, sizes []int) (*ViewStruct, error)
+	Expand(shape []int) (*BroadcastStruct, error)
+	Split(sizes []int, axis int) ([]*ViewStruct, error)
+	Chunk(n int, axis int) ([]*ViewStruct, error)
+	Unbind(axis int) ([]*ViewStruct, error)
+	IsContiguous() bool
+	Contiguous() *TensorStruct
 	Broadcast(shape []int) (*BroadcastStruct, error)
 
-	Add(*TensorStruct) (*TensorStruct, error)
-	Sub(*TensorStruct) (*TensorStruct, error)
-	Mul(*TensorStruct) (*TensorStruct, error)
-	Div(*TensorStruct) (*TensorStruct, error)
-
-	addBroadcast(*BroadcastStruct) (*TensorStruct, error)
-	subBroadcast(*BroadcastStruct) (*TensorStruct, error)
-	mulBroadcast(*BroadcastStruct) (*TensorStruct, error)
-	divBroadcast(*BroadcastStruct) (*TensorStruct, error)
+	Tile(reps []int) (*TensorStruct, error)
+	Repeat(n int, axis int) (*TensorStruct, error)
+	RepeatInterleave(n int, axis int) (*TensorStruct, error)
+
+	Add(other Operand) (*TensorStruct, error)
+	Sub(other Operand) (*TensorStruct, error)
+	Mul(other Operand) (*TensorStruct, error)
+	Div(other Operand) (*TensorStruct, error)
+	DivWith(other Operand, policy DivisionPolicy) (*TensorStruct, error)
+	AddInPlace(other Operand) error
+	SubInPlace(other Operand) error
+	MulInPlace(other Operand) error
+	DivInPlace(other Operand) error
+	AddScaled(alpha float64, other Operand) error
+
+	Sum(axes []int, keepDims bool) (*TensorStruct, error)
+	Mean(axes []int, keepDims bool) (*TensorStruct, error)
+	Prod(axes []int, keepDims bool) (*TensorStruct, error)
+	Max(axes []int, keepDims bool) (*TensorStruct, error)
+	Min(axes []int, keepDims bool) (*TensorStruct, error)
+	ArgMax(axes []int, keepDims bool) (*TensorStruct, error)
+	ArgMin(axes []int, keepDims bool) (*TensorStruct, error)
+	Var(axes []int, ddof int, keepDims bool) (*TensorStruct, error)
+	Std(axes []int, ddof int, keepDims bool) (*TensorStruct, error)
+	Norm(p float64, axes []int, keepDims bool) (*TensorStruct, error)
+	All(axes []int, keepDims bool) (*TensorStruct, error)
+	Any(axes []int, keepDims bool) (*TensorStruct, error)
+	Median(axes []int, keepDims bool) (*TensorStruct, error)
+	Quantile(q float64, axes []int, keepDims bool) (*TensorStruct, error)
+
+	Neg() *TensorStruct
+	Abs() *TensorStruct
+	Exp() *TensorStruct
+	Expm1() *TensorStruct
+	Log() *TensorStruct
+	Log1p() *TensorStruct
+	Sqrt() *TensorStruct
+	Rsqrt() *TensorStruct
+	Sin() *TensorStruct
+	Cos() *TensorStruct
+	Tan() *TensorStruct
+	Tanh() *TensorStruct
+	Sigmoid() *TensorStruct
+	Sign() *TensorStruct
+	Floor() *TensorStruct
+	Ceil() *TensorStruct
+	Round() *TensorStruct
+	IsNaN() *TensorStruct
+	IsInf() *TensorStruct
+	NanToNum(nan, posInf, negInf float64) *TensorStruct
+	HasNaN() bool
+	Pow(other Operand) (*TensorStruct, error)
+	Clamp(lo, hi float64) (*TensorStruct, error)
+	Apply(fn func(float64) float64) *TensorStruct
+	ApplyBinary(other Operand, fn func(x, y float64) float64) (*TensorStruct, error)
+
+	Eq(other Operand) (*TensorStruct, error)
+	Ne(other Operand) (*TensorStruct, error)
+	Lt(other Operand) (*TensorStruct, error)
+	Le(other Operand) (*TensorStruct, error)
+	Gt(other Operand) (*TensorStruct, error)
+	Ge(other Operand) (*TensorStruct, error)
+	LogicalAnd(other Operand) (*TensorStruct, error)
+	LogicalOr(other Operand) (*TensorStruct, error)
+	LogicalXor(other Operand) (*TensorStruct, error)
+	LogicalNot() *TensorStruct
+	MaskedFill(mask Operand, value float64) (*TensorStruct, error)
+	MaskedSelect(mask Operand) (*TensorStruct, error)
+
+	IndexSelect(axis int, idx Operand) (*TensorStruct, error)
+	Gather(axis int, idx Operand) (*TensorStruct, error)
+	Scatter(axis int, idx Operand, src Operand) (*TensorStruct, error)
+	ScatterAdd(axis int, idx Operand, src Operand) (*TensorStruct, error)
+	Take(idx Operand) (*TensorStruct, error)
+	Put(idx Operand, values Operand) error
+	IndexWith(indices ...Operand) (*TensorStruct, error)
+
+	MatMul(other Operand) (*TensorStruct, error)
+	Dot(other Operand) (*TensorStruct, error)
+	Inner(other Operand) (*TensorStruct, error)
+	Outer(other Operand) *TensorStruct
+	MatVec(other Operand) (*TensorStruct, error)
+	Kron(other Operand) *TensorStruct
+
+	Sort(axis int, descending bool) (*TensorStruct, *TensorStruct, error)
+	ArgSort(axis int, descending bool) (*TensorStruct, error)
+	TopK(k int, axis int) (*TensorStruct, *TensorStruct, error)
+	Unique() (*TensorStruct, *TensorStruct, *TensorStruct)
+	SearchSorted(values Operand, right bool) (*TensorStruct, error)
+	Histogram(bins int) (*TensorStruct, *TensorStruct, error)
+
+	CumSum(axis int) (*TensorStruct, error)
+	CumProd(axis int) (*TensorStruct, error)
+	CumMax(axis int) (*TensorStruct, error)
+	CumMin(axis int) (*TensorStruct, error)
+	LogCumSumExp(axis int) (*TensorStruct, error)
+	Diff(n int, axis int) (*TensorStruct, error)
 }
 
 // NewScalar creates a new scalar tensor
@@ -61,12 +170,18 @@ func NewScalar(data float64) *TensorStruct {
 	return &TensorStruct{
 		shape:  []int{},
 		stride: []int{},
-		data:   []float64{data},
+		data:   float64Storage{data},
 	}
 }
 
-// NewTensor creates a new tensor with the given shape and data
+// NewTensor creates a new float64 tensor with the given shape and data
 func NewTensor(shape []int, data []float64) (*TensorStruct, error) {
+	return NewTensorOf(shape, data)
+}
+
+// NewTensorOf creates a new tensor with the given shape and data, the dtype of
+// the tensor is taken from the element type of the data
+func NewTensorOf[T Element](shape []int, data []T) (*TensorStruct, error) {
 	// Check if any dimensions are negative
 	for _, dim := range shape {
 		if dim < 0 {
@@ -81,18 +196,19 @@ func NewTensor(shape []int, data []float64) (*TensorStruct, error) {
 
 	// Check if the shape is a scalar
 	if len(shape) == 0 || (len(shape) == 1 && shape[0] == 0) {
-		return NewScalar(data[0]), nil
+		return &TensorStruct{
+			shape:  []int{},
+			stride: []int{},
+			data:   storageOf(data[:1]),
+		}, nil
 	}
 
 	// Calculate the expected data size
-	expectedLength := 1
-	for _, dim := range shape {
-		expectedLength *= dim
-	}
+	expectedLength := shapeSize(shape)
 
-	// Check if we don't have enough data
+	// Check if we don't have enough data, data is never replicated here, use
+	// NewTensorFill or Tile to replicate it explicitly
 	if len(data) < expectedLength {
-		// TODO: Replicate data
 		return nil, fmt.Errorf("data length %d is less than shape capacity %d", len(data), expectedLength)
 	}
 
@@ -101,15 +217,29 @@ func NewTensor(shape []int, data []float64) (*TensorStruct, error) {
 		return nil, fmt.Errorf("data length %d exceeds shape capacity %d", len(data), expectedLength)
 	}
 
-	// Compute the stride
-	stride := computeStrides(shape)
-
 	// Create the tensor
+	return newTensor(shape, storageOf(data)), nil
+}
+
+// newTensor creates a tensor with packed strides around existing storage
+func newTensor(shape []int, data storage) *TensorStruct {
 	return &TensorStruct{
 		shape:  shape,
-		stride: stride,
+		stride: computeStrides(shape),
 		data:   data,
-	}, nil
+	}
+}
+
+// DataOf returns the underlying data of a tensor whose dtype matches T
+func DataOf[T Element](t *TensorStruct) ([]T, error) {
+	// Check if the storage holds elements of type T
+	data, ok := rawSlice(t.data).([]T)
+	if !ok {
+		return nil, fmt.Errorf("dtype mismatch: tensor is %v", t.DType())
+	}
+
+	// Return the data
+	return data, nil
 }
 
 // Shape returns the shape of the tensor
@@ -127,284 +257,190 @@ func (t *TensorStruct) Stride() []int {
 	return t.stride
 }
 
-// Data returns the data of the tensor
-func (t *TensorStruct) Data() []float64 {
+// Offset returns the offset of the tensor's first element in its data, which is always 0
+func (t *TensorStruct) Offset() int {
+	return 0
+}
+
+// storage returns the data of the tensor
+func (t *TensorStruct) storage() storage {
 	return t.data
 }
 
+// DType returns the dtype of the tensor
+func (t *TensorStruct) DType() DType {
+	return t.data.dtype()
+}
+
+// Data returns the data of the tensor as float64 values, for a float64 tensor
+// this is the underlying data, any other dtype is converted into a new slice
+func (t *TensorStruct) Data() []float64 {
+	return toFloat64s(t.data)
+}
+
 // String returns a string representation of the tensor
 func (t *TensorStruct) String() string {
+	data := t.Data()
+	dtype := t.DType()
 	switch len(t.shape) {
 	case 0:
-		return fmt.Sprintf("%.2f", t.data[0])
+		return formatValue(data[0], dtype)
 	case 1:
-		return fmt.Sprintf("[%s]", formatSlice(t.data))
+		return fmt.Sprintf("[%s]", formatSlice(data, dtype))
 	case 2:
 		rows := make([]string, t.shape[0])
 		for i := 0; i < t.shape[0]; i++ {
 			start := i * t.shape[1]
 			end := start + t.shape[1]
-			rows[i] = formatSlice(t.data[start:end])
+			rows[i] = formatSlice(data[start:end], dtype)
 		}
 		return "[\n " + strings.Join(rows, "\n ") + "\n]"
 	default:
-		return fmt.Sprintf("Tensor(shape=%v, data=[%s])", t.shape, formatSlice(t.data))
+		return fmt.Sprintf("Tensor(shape=%v, data=[%s])", t.shape, formatSlice(data, dtype))
 	}
 }
 
-// View returns a view of the tensor
-func (t *TensorStruct) View(shape []int) (*ViewStruct, error) {
-	return NewView(t).Reshape(shape)
-}
-
-// Broadcast returns a broadcasted tensor
-func (t *TensorStruct) Broadcast(shape []int) (*BroadcastStruct, error) {
-	return NewBroadcast(shape, t)
-}
-
-// Add adds another tensor to this tensor
-func (t *TensorStruct) Add(other *TensorStruct) (*TensorStruct, error) {
-	// Check if shapes not the same
-	if !reflect.DeepEqual(t.shape, other.shape) {
-		// Sort the tensors by rank
-		tensors := []*TensorStruct{t, other}
-		if other.Rank() < t.Rank() {
-			tensors = []*TensorStruct{other, t}
-		}
-
-		// Broadcast the smaller tensor to the shape of the larger tensor
-		broadcasted, err := NewBroadcast(tensors[1].shape, tensors[0])
-		if err != nil {
-			// If there is an error, return it
-			return nil, err
-		}
-
-		// Add the broadcasted tensor to the larger tensor
-		return tensors[1].addBroadcast(broadcasted)
-	}
-
-	// Initialize the result
-	result := make([]float64, len(t.data))
-
-	// Perform element-wise addition
-	for i := range t.data {
-		result[i] = t.data[i] + other.data[i]
+// At returns the element at the given index
+func (t *TensorStruct) At(idx ...int) (float64, error) {
+	// Convert the index to an offset
+	offset, err := offsetOf(t.shape, t.stride, idx)
+	if err != nil {
+		return 0, err
 	}
 
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
-}
-
-// Sub subtracts another tensor from this tensor
-func (t *TensorStruct) Sub(other *TensorStruct) (*TensorStruct, error) {
-	// Check if shapes are not the same
-	if !reflect.DeepEqual(t.shape, other.shape) {
-		// Sort the tensors by rank
-		tensors := []*TensorStruct{t, other}
-		if other.Rank() < t.Rank() {
-			tensors = []*TensorStruct{other, t}
-		}
-
-		// Broadcast the smaller tensor to the shape of the larger tensor
-		broadcasted, err := NewBroadcast(tensors[1].shape, tensors[0])
-		if err != nil {
-			// If there is an error, return it
-			return nil, err
-		}
+	// Return the element
+	return t.data.get(offset), nil
+}
 
-		// Subtract the broadcasted tensor from the larger tensor
-		return tensors[1].subBroadcast(broadcasted)
+// Set sets the element at the given index
+func (t *TensorStruct) Set(value float64, idx ...int) error {
+	// Convert the index to an offset
+	offset, err := offsetOf(t.shape, t.stride, idx)
+	if err != nil {
+		return err
 	}
 
-	// Initialize the result
-	result := make([]float64, len(t.data))
+	// Set the element
+	t.data.set(offset, value)
+	return nil
+}
 
-	// Perform element-wise subtraction
-	for i := range t.data {
-		result[i] = t.data[i] - other.data[i]
+// AtFlat returns the element at the given row-major flat index
+func (t *TensorStruct) AtFlat(idx int) (float64, error) {
+	// Convert the flat index to an offset
+	offset, err := flatOffsetOf(t.shape, t.stride, idx)
+	if err != nil {
+		return 0, err
 	}
 
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
-}
-
-// Mul multiplies this tensor by another tensor
-func (t *TensorStruct) Mul(other *TensorStruct) (*TensorStruct, error) {
-	// Check if shapes are not the same
-	if !reflect.DeepEqual(t.shape, other.shape) {
-		// Sort the tensors by rank
-		tensors := []*TensorStruct{t, other}
-		if other.Rank() < t.Rank() {
-			tensors = []*TensorStruct{other, t}
-		}
-
-		// Broadcast the smaller tensor to the shape of the larger tensor
-		broadcasted, err := NewBroadcast(tensors[1].shape, tensors[0])
-		if err != nil {
-			// If there is an error, return it
-			return nil, err
-		}
+	// Return the element
+	return t.data.get(offset), nil
+}
 
-		// Multiply the broadcasted tensor with the larger tensor
-		return tensors[1].mulBroadcast(broadcasted)
+// SetFlat sets the element at the given row-major flat index
+func (t *TensorStruct) SetFlat(value float64, idx int) error {
+	// Convert the flat index to an offset
+	offset, err := flatOffsetOf(t.shape, t.stride, idx)
+	if err != nil {
+		return err
 	}
 
-	// Initialize the result
-	result := make([]float64, len(t.data))
+	// Set the element
+	t.data.set(offset, value)
+	return nil
+}
 
-	// Perform element-wise multiplication
-	for i := range t.data {
-		result[i] = t.data[i] * other.data[i]
+// Item returns the only element of a tensor holding exactly one element
+func (t *TensorStruct) Item() (float64, error) {
+	// Check that there is exactly one element
+	if err := validItem(t.shape); err != nil {
+		return 0, err
 	}
 
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
-}
-
-// Div divides this tensor by another tensor
-func (t *TensorStruct) Div(other *TensorStruct) (*TensorStruct, error) {
-	// Check if shapes are not the same
-	if !reflect.DeepEqual(t.shape, other.shape) {
-		// Sort the tensors by rank
-		tensors := []*TensorStruct{t, other}
-		if other.Rank() < t.Rank() {
-			tensors = []*TensorStruct{other, t}
-		}
-
-		// Broadcast the smaller tensor to the shape of the larger tensor
-		broadcasted, err := NewBroadcast(tensors[1].shape, tensors[0])
-		if err != nil {
-			// If there is an error, return it
-			return nil, err
-		}
+	// Return the element
+	return t.data.get(0), nil
+}
 
-		// Divide the broadcasted tensor with the larger tensor
-		return tensors[1].divBroadcast(broadcasted)
+// AsType returns a copy of the tensor converted to the given dtype
+func (t *TensorStruct) AsType(dtype DType) (*TensorStruct, error) {
+	// Check if the dtype is supported
+	if !dtype.valid() {
+		return nil, fmt.Errorf("unsupported dtype: %v", dtype)
 	}
 
-	// Initialize the result
-	result := make([]float64, len(t.data))
-
-	// Perform element-wise division
-	for i := range t.data {
-		if other.data[i] == 0 {
-			return nil, fmt.Errorf("division by zero")
-		}
-		result[i] = t.data[i] / other.data[i]
+	// Convert every element
+	data := newStorage(dtype, t.data.len())
+	for i := 0; i < t.data.len(); i++ {
+		copyElement(data, i, t.data, i)
 	}
 
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
+	// Return the converted tensor
+	return newTensor(t.shape, data), nil
 }
 
-// addBroadcast adds a broadcast to this tensor
-func (t *TensorStruct) addBroadcast(b *BroadcastStruct) (*TensorStruct, error) {
-	// Check if shapes not the same
-	if !reflect.DeepEqual(t.shape, b.broadcastShape) {
-		return nil, fmt.Errorf("shape mismatch")
-	}
-
-	// Initialize the result
-	result := make([]float64, len(t.data))
-
-	// Perform element-wise addition
-	for i := range t.data {
-		result[i] = t.data[i] + b.GetFlat(i)
-	}
-
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
+// View returns a view of the tensor with the given shape, one dimension may
+// be -1 to infer it from the size of the tensor
+func (t *TensorStruct) View(shape []int) (*ViewStruct, error) {
+	return NewView(t).Reshape(shape)
 }
 
-// subBroadcast subtracts a broadcast from this tensor
-func (t *TensorStruct) subBroadcast(b *BroadcastStruct) (*TensorStruct, error) {
-	// Check if shapes not the same
-	if !reflect.DeepEqual(t.shape, b.broadcastShape) {
-		return nil, fmt.Errorf("shape mismatch")
-	}
+// Slice returns a view of part of the tensor, see ViewStruct.Slice
+func (t *TensorStruct) Slice(specs ...SliceSpec) (*ViewStruct, error) {
+	return NewView(t).Slice(specs...)
+}
 
-	// Initialize the result
-	result := make([]float64, len(t.data))
+// Transpose returns a view of the tensor with the order of the axes reversed
+func (t *TensorStruct) Transpose() *ViewStruct {
+	return NewView(t).Transpose()
+}
 
-	// Perform element-wise subtraction
-	for i := range t.data {
-		result[i] = t.data[i] - b.GetFlat(i)
-	}
+// Permute returns a view of the tensor with the axes reordered
+func (t *TensorStruct) Permute(axes ...int) (*ViewStruct, error) {
+	return NewView(t).Permute(axes...)
+}
 
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
+// SwapAxes returns a view of the tensor with the two axes exchanged
+func (t *TensorStruct) SwapAxes(a, b int) (*ViewStruct, error) {
+	return NewView(t).SwapAxes(a, b)
 }
 
-// mulBroadcast multiplies a broadcast with this tensor
-func (t *TensorStruct) mulBroadcast(b *BroadcastStruct) (*TensorStruct, error) {
-	// Check if shapes not the same
-	if !reflect.DeepEqual(t.shape, b.broadcastShape) {
-		return nil, fmt.Errorf("shape mismatch")
-	}
+// MoveAxis returns a view of the tensor with the axis at src moved to dst
+func (t *TensorStruct) MoveAxis(src, dst int) (*ViewStruct, error) {
+	return NewView(t).MoveAxis(src, dst)
+}
 
-	// Initialize the result
-	result := make([]float64, len(t.data))
+// IsContiguous reports whether the tensor is packed, which is always true
+func (t *TensorStruct) IsContiguous() bool {
+	return true
+}
 
-	// Perform element-wise multiplication
-	for i := range t.data {
-		result[i] = t.data[i] * b.GetFlat(i)
-	}
+// Contiguous returns the tensor itself, since it is always packed
+func (t *TensorStruct) Contiguous() *TensorStruct {
+	return t
+}
 
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
+// Broadcast returns a broadcasted tensor
+func (t *TensorStruct) Broadcast(shape []int) (*BroadcastStruct, error) {
+	return NewBroadcast(shape, t)
 }
 
-// divBroadcast divides this tensor by a broadcast
-func (t *TensorStruct) divBroadcast(b *BroadcastStruct) (*TensorStruct, error) {
-	// Check if shapes not the same
-	if !reflect.DeepEqual(t.shape, b.broadcastShape) {
-		return nil, fmt.Errorf("shape mismatch")
-	}
+// Add adds another tensor, view or broadcast to this tensor
+func (t *TensorStruct) Add(other Operand) (*TensorStruct, error) {
+	return add(t, other)
+}
 
-	// Initialize the result
-	result := make([]float64, len(t.data))
+// Sub subtracts another tensor, view or broadcast from this tensor
+func (t *TensorStruct) Sub(other Operand) (*TensorStruct, error) {
+	return sub(t, other)
+}
 
-	// Perform element-wise division
-	for i := range t.data {
-		val := b.GetFlat(i)
-		if val == 0 {
-			return nil, fmt.Errorf("division by zero")
-		}
-		result[i] = t.data[i] / val
-	}
+// Mul multiplies this tensor by another tensor, view or broadcast
+func (t *TensorStruct) Mul(other Operand) (*TensorStruct, error) {
+	return mul(t, other)
+}
 
-	// Return the new tensor, with the result data
-	return &TensorStruct{
-		shape:  t.shape,
-		stride: t.stride,
-		data:   result,
-	}, nil
+// Div divides this tensor by another tensor, view or broadcast, dividing by zero
+// follows the current division policy
+func (t *TensorStruct) Div(other Operand) (*TensorStruct, error) {
+	return div(t, other)
 }
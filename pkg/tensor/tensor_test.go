@@ -129,6 +129,36 @@ func TestArithmeticOps(t *testing.T) {
 			expectedMul:   []float64{5.0, 12.0, 21.0, 32.0},
 			expectedDiv:   []float64{0.2, 0.333333, 0.428571, 0.5},
 		},
+		{
+			name:          "BroadcastColumnAndRow",
+			t1:            mustNewTensor(t, []int{3, 1}, []float64{1.0, 2.0, 3.0}),
+			t2:            mustNewTensor(t, []int{1, 4}, []float64{1.0, 2.0, 4.0, 8.0}),
+			expectedShape: []int{3, 4},
+			expectedAdd:   []float64{2.0, 3.0, 5.0, 9.0, 3.0, 4.0, 6.0, 10.0, 4.0, 5.0, 7.0, 11.0},
+			expectedSub:   []float64{0.0, -1.0, -3.0, -7.0, 1.0, 0.0, -2.0, -6.0, 2.0, 1.0, -1.0, -5.0},
+			expectedMul:   []float64{1.0, 2.0, 4.0, 8.0, 2.0, 4.0, 8.0, 16.0, 3.0, 6.0, 12.0, 24.0},
+			expectedDiv:   []float64{1.0, 0.5, 0.25, 0.125, 2.0, 1.0, 0.5, 0.25, 3.0, 1.5, 0.75, 0.375},
+		},
+		{
+			name:          "BroadcastLowerRankLeft",
+			t1:            mustNewTensor(t, []int{2}, []float64{10.0, 20.0}),
+			t2:            mustNewTensor(t, []int{2, 2}, []float64{1.0, 2.0, 4.0, 5.0}),
+			expectedShape: []int{2, 2},
+			expectedAdd:   []float64{11.0, 22.0, 14.0, 25.0},
+			expectedSub:   []float64{9.0, 18.0, 6.0, 15.0},
+			expectedMul:   []float64{10.0, 40.0, 40.0, 100.0},
+			expectedDiv:   []float64{10.0, 10.0, 2.5, 4.0},
+		},
+		{
+			name:          "BroadcastScalarLeft",
+			t1:            NewScalar(12.0),
+			t2:            mustNewTensor(t, []int{3}, []float64{1.0, 2.0, 3.0}),
+			expectedShape: []int{3},
+			expectedAdd:   []float64{13.0, 14.0, 15.0},
+			expectedSub:   []float64{11.0, 10.0, 9.0},
+			expectedMul:   []float64{12.0, 24.0, 36.0},
+			expectedDiv:   []float64{12.0, 6.0, 4.0},
+		},
 		{
 			name:          "DifferentShape",
 			t1:            mustNewTensor(t, []int{2}, []float64{1.0, 2.0}),
@@ -190,3 +220,95 @@ func TestArithmeticOps(t *testing.T) {
 		})
 	}
 }
+
+// TestElementAccess tests reading and writing elements of a tensor
+func TestElementAccess(t *testing.T) {
+	tensor := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	// Test At
+	testCases := []struct {
+		name      string
+		idx       []int
+		expected  float64
+		expectErr bool
+	}{
+		{"First", []int{0, 0}, 1, false},
+		{"Middle", []int{1, 1}, 5, false},
+		{"Last", []int{1, 2}, 6, false},
+		{"OutOfBounds", []int{2, 0}, 0, true},
+		{"Negative", []int{0, -1}, 0, true},
+		{"TooFewIndices", []int{1}, 0, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tensor.At(tc.idx...)
+			if (err != nil) != tc.expectErr {
+				t.Fatalf("Expected error %v, got %v", tc.expectErr, err)
+			}
+			if err == nil {
+				checkEqual(t, "Value", tc.expected, got)
+			}
+		})
+	}
+
+	// Test Set and SetFlat
+	t.Run("Set", func(t *testing.T) {
+		target := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+		if err := target.Set(10, 1, 0); err != nil {
+			t.Fatalf("Set: expected no error, got %v", err)
+		}
+		if err := target.SetFlat(20, 1); err != nil {
+			t.Fatalf("SetFlat: expected no error, got %v", err)
+		}
+		checkEqual(t, "Data", []float64{1, 20, 10, 4}, target.Data())
+
+		if err := target.Set(1, 0, 2); err == nil {
+			t.Error("Set: expected out of bounds error, got nil")
+		}
+		if err := target.SetFlat(1, 4); err == nil {
+			t.Error("SetFlat: expected out of bounds error, got nil")
+		}
+	})
+
+	// Test AtFlat
+	t.Run("AtFlat", func(t *testing.T) {
+		got, err := tensor.AtFlat(4)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		checkEqual(t, "Value", 5.0, got)
+		if _, err := tensor.AtFlat(-1); err == nil {
+			t.Error("Expected out of bounds error, got nil")
+		}
+	})
+
+	// Test Item
+	t.Run("Item", func(t *testing.T) {
+		got, err := NewScalar(3.5).Item()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		checkEqual(t, "Value", 3.5, got)
+
+		got, err = mustNewTensor(t, []int{1, 1}, []float64{2}).Item()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		checkEqual(t, "Value", 2.0, got)
+
+		if _, err := tensor.Item(); err == nil {
+			t.Error("Expected error for multi-element item, got nil")
+		}
+	})
+
+	// Test typed storage
+	t.Run("TypedSet", func(t *testing.T) {
+		ints, _ := NewTensorOf([]int{2}, []int32{1, 2})
+		if err := ints.Set(7.9, 1); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		data, _ := DataOf[int32](ints)
+		checkEqual(t, "Data", []int32{1, 7}, data)
+	})
+}
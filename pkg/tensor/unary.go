@@ -0,0 +1,314 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+)
+
+// floatType returns the dtype produced by floating point math on the dtype,
+// floating point dtypes are kept and any other dtype becomes Float64
+func floatType(dtype DType) DType {
+	return divisionType(dtype, dtype)
+}
+
+// unary applies fn to every element of x, storing the result with the given dtype
+func unary(x Operand, dtype DType, fn func(v float64) float64) *TensorStruct {
+	// Initialize the result
+	shape := x.Shape()
+	stride := x.Stride()
+	data := x.storage()
+	result := newStorage(dtype, shapeSize(shape))
+
+	// Apply fn to every element in row-major order across the workers
+	parallelFor(result.len(), 1, func(start, end int) {
+		idx := unravelIndex(start, shape)
+		for i := start; i < end; i++ {
+			result.set(i, fn(data.get(offsetAt(x, stride, idx))))
+			nextIndex(idx, shape)
+		}
+	})
+
+	// Return the result
+	return newTensor(shape, result)
+}
+
+// negate returns -v
+func negate(v float64) float64 {
+	return -v
+}
+
+// rsqrt returns the reciprocal of the square root of v
+func rsqrt(v float64) float64 {
+	return 1 / math.Sqrt(v)
+}
+
+// sigmoid returns the logistic sigmoid of v, computed without overflow for large |v|
+func sigmoid(v float64) float64 {
+	if v >= 0 {
+		return 1 / (1 + math.Exp(-v))
+	}
+	e := math.Exp(v)
+	return e / (1 + e)
+}
+
+// sign returns -1, 0 or 1 depending on the sign of v, NaN stays NaN
+func sign(v float64) float64 {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	default:
+		return v
+	}
+}
+
+// clamp limits every element of x to [lo, hi]
+func clamp(x Operand, lo, hi float64) (*TensorStruct, error) {
+	if lo > hi {
+		return nil, fmt.Errorf("invalid clamp bounds: min %v is greater than max %v", lo, hi)
+	}
+	return unary(x, x.DType(), func(v float64) float64 {
+		return math.Max(lo, math.Min(hi, v))
+	}), nil
+}
+
+// pow raises every element of a to the power of the matching element of b, broadcasting both
+func pow(a Operand, b Operand) (*TensorStruct, error) {
+	return elementwise(a, b, floatType(promoteTypes(a.DType(), b.DType())), floatOp(func(x, y float64) (float64, error) {
+		return math.Pow(x, y), nil
+	}))
+}
+
+// applyBinary applies fn to every pair of elements of a and b, broadcasting both
+func applyBinary(a Operand, b Operand, fn func(x, y float64) float64) (*TensorStruct, error) {
+	return elementwise(a, b, floatType(promoteTypes(a.DType(), b.DType())), floatOp(func(x, y float64) (float64, error) {
+		return fn(x, y), nil
+	}))
+}
+
+// Neg negates every element of the tensor
+func (t *TensorStruct) Neg() *TensorStruct {
+	return unary(t, arithmeticType(t.DType(), t.DType()), negate)
+}
+
+// Abs returns the absolute value of every element of the tensor
+func (t *TensorStruct) Abs() *TensorStruct {
+	return unary(t, arithmeticType(t.DType(), t.DType()), math.Abs)
+}
+
+// Exp returns e raised to every element of the tensor
+func (t *TensorStruct) Exp() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Exp)
+}
+
+// Expm1 returns e raised to every element of the tensor minus 1, accurate for
+// elements near 0
+func (t *TensorStruct) Expm1() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Expm1)
+}
+
+// Log returns the natural logarithm of every element of the tensor
+func (t *TensorStruct) Log() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Log)
+}
+
+// Log1p returns the natural logarithm of 1 plus every element of the tensor,
+// accurate for elements near 0
+func (t *TensorStruct) Log1p() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Log1p)
+}
+
+// Sqrt returns the square root of every element of the tensor
+func (t *TensorStruct) Sqrt() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Sqrt)
+}
+
+// Rsqrt returns the reciprocal of the square root of every element of the tensor
+func (t *TensorStruct) Rsqrt() *TensorStruct {
+	return unary(t, floatType(t.DType()), rsqrt)
+}
+
+// Sin returns the sine of every element of the tensor
+func (t *TensorStruct) Sin() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Sin)
+}
+
+// Cos returns the cosine of every element of the tensor
+func (t *TensorStruct) Cos() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Cos)
+}
+
+// Tan returns the tangent of every element of the tensor
+func (t *TensorStruct) Tan() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Tan)
+}
+
+// Tanh returns the hyperbolic tangent of every element of the tensor
+func (t *TensorStruct) Tanh() *TensorStruct {
+	return unary(t, floatType(t.DType()), math.Tanh)
+}
+
+// Sigmoid returns the logistic sigmoid 1 / (1 + e^-x) of every element of
+// the tensor
+func (t *TensorStruct) Sigmoid() *TensorStruct {
+	return unary(t, floatType(t.DType()), sigmoid)
+}
+
+// Sign returns -1, 0 or 1 for every element of the tensor depending on its sign
+func (t *TensorStruct) Sign() *TensorStruct {
+	return unary(t, arithmeticType(t.DType(), t.DType()), sign)
+}
+
+// Floor rounds every element of the tensor down
+func (t *TensorStruct) Floor() *TensorStruct {
+	return unary(t, t.DType(), math.Floor)
+}
+
+// Ceil rounds every element of the tensor up
+func (t *TensorStruct) Ceil() *TensorStruct {
+	return unary(t, t.DType(), math.Ceil)
+}
+
+// Round rounds every element of the tensor to the nearest integer, rounding
+// halves to even
+func (t *TensorStruct) Round() *TensorStruct {
+	return unary(t, t.DType(), math.RoundToEven)
+}
+
+// Pow raises every element of the tensor to the power of the matching
+// element of other, broadcasting both
+func (t *TensorStruct) Pow(other Operand) (*TensorStruct, error) {
+	return pow(t, other)
+}
+
+// Clamp limits every element of the tensor to the range [lo, hi]
+func (t *TensorStruct) Clamp(lo, hi float64) (*TensorStruct, error) {
+	return clamp(t, lo, hi)
+}
+
+// Apply returns the result of fn applied to every element of the tensor,
+// fn may be called from several goroutines at once
+func (t *TensorStruct) Apply(fn func(float64) float64) *TensorStruct {
+	return unary(t, floatType(t.DType()), fn)
+}
+
+// ApplyBinary returns the result of fn applied to every pair of elements of
+// the tensor and other, broadcasting both, fn may be called from several
+// goroutines at once
+func (t *TensorStruct) ApplyBinary(other Operand, fn func(x, y float64) float64) (*TensorStruct, error) {
+	return applyBinary(t, other, fn)
+}
+
+// Neg negates every element of the view
+func (v *ViewStruct) Neg() *TensorStruct {
+	return unary(v, arithmeticType(v.DType(), v.DType()), negate)
+}
+
+// Abs returns the absolute value of every element of the view
+func (v *ViewStruct) Abs() *TensorStruct {
+	return unary(v, arithmeticType(v.DType(), v.DType()), math.Abs)
+}
+
+// Exp returns e raised to every element of the view
+func (v *ViewStruct) Exp() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Exp)
+}
+
+// Expm1 returns e raised to every element of the view minus 1, accurate for
+// elements near 0
+func (v *ViewStruct) Expm1() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Expm1)
+}
+
+// Log returns the natural logarithm of every element of the view
+func (v *ViewStruct) Log() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Log)
+}
+
+// Log1p returns the natural logarithm of 1 plus every element of the view,
+// accurate for elements near 0
+func (v *ViewStruct) Log1p() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Log1p)
+}
+
+// Sqrt returns the square root of every element of the view
+func (v *ViewStruct) Sqrt() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Sqrt)
+}
+
+// Rsqrt returns the reciprocal of the square root of every element of the view
+func (v *ViewStruct) Rsqrt() *TensorStruct {
+	return unary(v, floatType(v.DType()), rsqrt)
+}
+
+// Sin returns the sine of every element of the view
+func (v *ViewStruct) Sin() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Sin)
+}
+
+// Cos returns the cosine of every element of the view
+func (v *ViewStruct) Cos() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Cos)
+}
+
+// Tan returns the tangent of every element of the view
+func (v *ViewStruct) Tan() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Tan)
+}
+
+// Tanh returns the hyperbolic tangent of every element of the view
+func (v *ViewStruct) Tanh() *TensorStruct {
+	return unary(v, floatType(v.DType()), math.Tanh)
+}
+
+// Sigmoid returns the logistic sigmoid 1 / (1 + e^-x) of every element of
+// the view
+func (v *ViewStruct) Sigmoid() *TensorStruct {
+	return unary(v, floatType(v.DType()), sigmoid)
+}
+
+// Sign returns -1, 0 or 1 for every element of the view depending on its sign
+func (v *ViewStruct) Sign() *TensorStruct {
+	return unary(v, arithmeticType(v.DType(), v.DType()), sign)
+}
+
+// Floor rounds every element of the view down
+func (v *ViewStruct) Floor() *TensorStruct {
+	return unary(v, v.DType(), math.Floor)
+}
+
+// Ceil rounds every element of the view up
+func (v *ViewStruct) Ceil() *TensorStruct {
+	return unary(v, v.DType(), math.Ceil)
+}
+
+// Round rounds every element of the view to the nearest integer, rounding
+// halves to even
+func (v *ViewStruct) Round() *TensorStruct {
+	return unary(v, v.DType(), math.RoundToEven)
+}
+
+// Pow raises every element of the view to the power of the matching
+// element of other, broadcasting both
+func (v *ViewStruct) Pow(other Operand) (*TensorStruct, error) {
+	return pow(v, other)
+}
+
+// Clamp limits every element of the view to the range [lo, hi]
+func (v *ViewStruct) Clamp(lo, hi float64) (*TensorStruct, error) {
+	return clamp(v, lo, hi)
+}
+
+// Apply returns the result of fn applied to every element of the view,
+// fn may be called from several goroutines at once
+func (v *ViewStruct) Apply(fn func(float64) float64) *TensorStruct {
+	return unary(v, floatType(v.DType()), fn)
+}
+
+// ApplyBinary returns the result of fn applied to every pair of elements of
+// the view and other, broadcasting both, fn may be called from several
+// goroutines at once
+func (v *ViewStruct) ApplyBinary(other Operand, fn func(x, y float64) float64) (*TensorStruct, error) {
+	return applyBinary(v, other, fn)
+}
@@ -0,0 +1,121 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+// TestUnaryOps tests the elementwise unary math functions
+func TestUnaryOps(t *testing.T) {
+	x := mustNewTensor(t, []int{4}, []float64{-1.5, 0, 0.5, 2})
+	positive := mustNewTensor(t, []int{3}, []float64{1, 4, 0.25})
+
+	testCases := []struct {
+		name         string
+		result       *TensorStruct
+		expectedData []float64
+	}{
+		{"Neg", x.Neg(), []float64{1.5, 0, -0.5, -2}},
+		{"Abs", x.Abs(), []float64{1.5, 0, 0.5, 2}},
+		{"Exp", x.Exp(), []float64{math.Exp(-1.5), 1, math.Exp(0.5), math.Exp(2)}},
+		{"Expm1", x.Expm1(), []float64{math.Expm1(-1.5), 0, math.Expm1(0.5), math.Expm1(2)}},
+		{"Log", positive.Log(), []float64{0, math.Log(4), math.Log(0.25)}},
+		{"Log1p", positive.Log1p(), []float64{math.Log(2), math.Log(5), math.Log(1.25)}},
+		{"Sqrt", positive.Sqrt(), []float64{1, 2, 0.5}},
+		{"Rsqrt", positive.Rsqrt(), []float64{1, 0.5, 2}},
+		{"Sin", x.Sin(), []float64{math.Sin(-1.5), 0, math.Sin(0.5), math.Sin(2)}},
+		{"Cos", x.Cos(), []float64{math.Cos(-1.5), 1, math.Cos(0.5), math.Cos(2)}},
+		{"Tan", x.Tan(), []float64{math.Tan(-1.5), 0, math.Tan(0.5), math.Tan(2)}},
+		{"Tanh", x.Tanh(), []float64{math.Tanh(-1.5), 0, math.Tanh(0.5), math.Tanh(2)}},
+		{"Sigmoid", x.Sigmoid(), []float64{1 / (1 + math.Exp(1.5)), 0.5, 1 / (1 + math.Exp(-0.5)), 1 / (1 + math.Exp(-2))}},
+		{"Sign", x.Sign(), []float64{-1, 0, 1, 1}},
+		{"Floor", x.Floor(), []float64{-2, 0, 0, 2}},
+		{"Ceil", x.Ceil(), []float64{-1, 0, 1, 2}},
+		{"Round", x.Round(), []float64{-2, 0, 0, 2}},
+		{"Apply", x.Apply(func(v float64) float64 { return v * v }), []float64{2.25, 0, 0.25, 4}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checkEqual(t, "Shape", []int{len(tc.expectedData)}, tc.result.Shape())
+			if !almostEqual(tc.expectedData, tc.result.Data()) {
+				t.Errorf("Expected data %v, got %v", tc.expectedData, tc.result.Data())
+			}
+		})
+	}
+}
+
+// TestUnaryExtremes tests unary functions at the edges of their domains
+func TestUnaryExtremes(t *testing.T) {
+	x := mustNewTensor(t, []int{3}, []float64{-1000, 1000, math.NaN()})
+
+	sigmoid := x.Sigmoid().Data()
+	checkEqual(t, "Sigmoid", []float64{0, 1}, sigmoid[:2])
+	if !math.IsNaN(sigmoid[2]) {
+		t.Errorf("Expected NaN sigmoid, got %v", sigmoid[2])
+	}
+
+	if !math.IsNaN(x.Sign().Data()[2]) {
+		t.Error("Expected sign of NaN to be NaN")
+	}
+
+	logs := mustNewTensor(t, []int{2}, []float64{0, -1}).Log().Data()
+	if !math.IsInf(logs[0], -1) || !math.IsNaN(logs[1]) {
+		t.Errorf("Expected [-Inf NaN], got %v", logs)
+	}
+}
+
+// TestUnaryDTypes tests the dtypes produced by unary functions
+func TestUnaryDTypes(t *testing.T) {
+	ints, _ := NewTensorOf([]int{2}, []int32{-3, 4})
+	floats, _ := NewTensorOf([]int{2}, []float32{1, 4})
+
+	checkEqual(t, "Neg Int", Int32, ints.Neg().DType())
+	checkEqual(t, "Neg Int Data", []float64{3, -4}, ints.Neg().Data())
+	checkEqual(t, "Abs Int", Int32, ints.Abs().DType())
+	checkEqual(t, "Exp Int", Float64, ints.Exp().DType())
+	checkEqual(t, "Sqrt Float32", Float32, floats.Sqrt().DType())
+	checkEqual(t, "Floor Int", Int32, ints.Floor().DType())
+}
+
+// TestClampPowApplyBinary tests the parameterized elementwise functions
+func TestClampPowApplyBinary(t *testing.T) {
+	x := mustNewTensor(t, []int{2, 2}, []float64{-2, 1, 3, 5})
+
+	clamped, err := x.Clamp(0, 4)
+	if err != nil {
+		t.Fatalf("Clamp: expected no error, got %v", err)
+	}
+	checkEqual(t, "Clamp", []float64{0, 1, 3, 4}, clamped.Data())
+	if _, err := x.Clamp(4, 0); err == nil {
+		t.Error("Clamp: expected error for inverted bounds")
+	}
+
+	// Pow broadcasts a column of exponents across the rows
+	exponents := mustNewTensor(t, []int{2, 1}, []float64{2, 0.5})
+	powers, err := mustNewTensor(t, []int{2, 2}, []float64{3, 4, 9, 16}).Pow(exponents)
+	if err != nil {
+		t.Fatalf("Pow: expected no error, got %v", err)
+	}
+	checkEqual(t, "Pow", []float64{9, 16, 3, 4}, powers.Data())
+
+	squared, err := x.Pow(NewScalar(2))
+	if err != nil {
+		t.Fatalf("Pow: expected no error, got %v", err)
+	}
+	checkEqual(t, "Pow Scalar", []float64{4, 1, 9, 25}, squared.Data())
+
+	maxed, err := x.ApplyBinary(NewScalar(2), math.Max)
+	if err != nil {
+		t.Fatalf("ApplyBinary: expected no error, got %v", err)
+	}
+	checkEqual(t, "ApplyBinary", []float64{2, 2, 3, 5}, maxed.Data())
+
+	if _, err := x.Pow(mustNewTensor(t, []int{3}, []float64{1, 2, 3})); err == nil {
+		t.Error("Pow: expected error for incompatible shapes")
+	}
+
+	// Unary functions read views through their strides
+	transposed := x.Transpose().Abs()
+	checkEqual(t, "Transposed Abs", []float64{2, 3, 1, 5}, transposed.Data())
+}
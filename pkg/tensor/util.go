@@ -5,14 +5,98 @@ import (
 	"strings"
 )
 
-// formatSlice formats a slice of floats as a string
-func formatSlice(data []float64) string {
+// formatSlice formats a slice of elements of the given dtype as a string
+func formatSlice(data []float64, dtype DType) string {
 	// Format the slice
 	formatted := make([]string, len(data))
 	for i, v := range data {
-		formatted[i] = fmt.Sprintf("%.2f", v)
+		formatted[i] = formatValue(v, dtype)
 	}
 
 	// Join the formatted slice
 	return strings.Join(formatted, " ")
 }
+
+// shapeSize returns the number of elements described by a shape
+func shapeSize(shape []int) int {
+	size := 1
+	for _, dim := range shape {
+		size *= dim
+	}
+	return size
+}
+
+// unravelIndex converts a flat row-major index into an index into the shape
+func unravelIndex(flat int, shape []int) []int {
+	idx := make([]int, len(shape))
+	for i := len(shape) - 1; i >= 0; i-- {
+		idx[i] = flat % shape[i]
+		flat /= shape[i]
+	}
+	return idx
+}
+
+// nextIndex advances idx to the next index of the shape in row-major order,
+// wrapping back to all zeros after the last index
+func nextIndex(idx []int, shape []int) {
+	for i := len(shape) - 1; i >= 0; i-- {
+		idx[i]++
+		if idx[i] < shape[i] {
+			return
+		}
+		idx[i] = 0
+	}
+}
+
+// normalizeAxis converts a possibly negative axis into an index in [0, rank)
+func normalizeAxis(axis int, rank int) (int, error) {
+	if axis < -rank || axis >= rank {
+		return 0, fmt.Errorf("axis %d out of range for rank %d", axis, rank)
+	}
+	if axis < 0 {
+		axis += rank
+	}
+	return axis, nil
+}
+
+// offsetOf converts an index into a shape to an offset into data laid out
+// with the given strides, checking that the index is within bounds
+func offsetOf(shape []int, stride []int, idx []int) (int, error) {
+	// Check if enough indices are provided
+	if len(idx) != len(shape) {
+		return 0, fmt.Errorf("index dimension mismatch: expected %d, got %d", len(shape), len(idx))
+	}
+
+	// Check each index and accumulate the offset
+	offset := 0
+	for i, v := range idx {
+		if v < 0 || v >= shape[i] {
+			return 0, fmt.Errorf("index %d out of bounds for axis %d with size %d", v, i, shape[i])
+		}
+		offset += v * stride[i]
+	}
+
+	// Return the offset
+	return offset, nil
+}
+
+// flatOffsetOf converts a flat row-major index into a shape to an offset into
+// data laid out with the given strides, checking that the index is within bounds
+func flatOffsetOf(shape []int, stride []int, flat int) (int, error) {
+	// Check if the flat index is within bounds
+	size := shapeSize(shape)
+	if flat < 0 || flat >= size {
+		return 0, fmt.Errorf("flat index %d out of bounds for size %d", flat, size)
+	}
+
+	// Convert the flat index to an offset
+	return offsetOf(shape, stride, unravelIndex(flat, shape))
+}
+
+// validItem checks that a shape holds exactly one element
+func validItem(shape []int) error {
+	if size := shapeSize(shape); size != 1 {
+		return fmt.Errorf("item requires exactly one element, got %d", size)
+	}
+	return nil
+}
@@ -5,10 +5,12 @@ import (
 	"strings"
 )
 
-// ViewStruct represents a view of a tensor with its own shape and stride
+// ViewStruct represents a view of a tensor with its own shape and stride,
+// starting at an offset into the tensor's data
 type ViewStruct struct {
 	shape  []int
 	stride []int
+	offset int
 	tensor *TensorStruct
 }
 
@@ -26,20 +28,144 @@ func validReshape(currentShape []int, desiredShape []int) bool {
 		desiredSize *= dim
 	}
 
+	// Check if any dimensions are negative
+	for _, dim := range desiredShape {
+		if dim < 0 {
+			return false
+		}
+	}
+
 	// Check if the current size is equal to the desired size
 	return currentSize == desiredSize
 }
 
 // View interface extends the Tensor interface
 type View interface {
-	Shape() []int
-	Stride() []int
+	Operand
+
 	Data() []float64
 
 	String() string
 
+	At(idx ...int) (float64, error)
+	Set(value float64, idx ...int) error
+	AtFlat(idx int) (float64, error)
+	SetFlat(value float64, idx int) error
+	Item() (float64, error)
+
 	View(shape []int) (*ViewStruct, error)
 	Reshape(shape []int) (*ViewStruct, error)
+	ReshapeNoCopy(shape []int) (*ViewStruct, error)
+	Materialize() *TensorStruct
+	Slice(specs ...SliceSpec) (*ViewStruct, error)
+	Transpose() *ViewStruct
+	Permute(axes ...int) (*ViewStruct, error)
+	SwapAxes(a, b int) (*ViewStruct, error)
+	MoveAxis(src, dst int) (*ViewStruct, error)
+	Squeeze(axes ...int) (*ViewStruct, error)
+	Unsqueeze(axis int) (*ViewStruct, error)
+	Flatten(start, end int) (*ViewStruct, error)
+	Unflatten(axis int, sizes []int) (*ViewStruct, error)
+	Expand(shape []int) (*BroadcastStruct, error)
+	Split(sizes []int, axis int) ([]*ViewStruct, error)
+	Chunk(n int, axis int) ([]*ViewStruct, error)
+	Unbind(axis int) ([]*ViewStruct, error)
+	IsContiguous() bool
+	Contiguous() *TensorStruct
+
+	Add(other Operand) (*TensorStruct, error)
+	Sub(other Operand) (*TensorStruct, error)
+	Mul(other Operand) (*TensorStruct, error)
+	Div(other Operand) (*TensorStruct, error)
+	DivWith(other Operand, policy DivisionPolicy) (*TensorStruct, error)
+	AddInPlace(other Operand) error
+	SubInPlace(other Operand) error
+	MulInPlace(other Operand) error
+	DivInPlace(other Operand) error
+	AddScaled(alpha float64, other Operand) error
+
+	Sum(axes []int, keepDims bool) (*TensorStruct, error)
+	Mean(axes []int, keepDims bool) (*TensorStruct, error)
+	Prod(axes []int, keepDims bool) (*TensorStruct, error)
+	Max(axes []int, keepDims bool) (*TensorStruct, error)
+	Min(axes []int, keepDims bool) (*TensorStruct, error)
+	ArgMax(axes []int, keepDims bool) (*TensorStruct, error)
+	ArgMin(axes []int, keepDims bool) (*TensorStruct, error)
+	Var(axes []int, ddof int, keepDims bool) (*TensorStruct, error)
+	Std(axes []int, ddof int, keepDims bool) (*TensorStruct, error)
+	Norm(p float64, axes []int, keepDims bool) (*TensorStruct, error)
+	All(axes []int, keepDims bool) (*TensorStruct, error)
+	Any(axes []int, keepDims bool) (*TensorStruct, error)
+	Median(axes []int, keepDims bool) (*TensorStruct, error)
+	Quantile(q float64, axes []int, keepDims bool) (*TensorStruct, error)
+
+	Neg() *TensorStruct
+	Abs() *TensorStruct
+	Exp() *TensorStruct
+	Expm1() *TensorStruct
+	Log() *TensorStruct
+	Log1p() *TensorStruct
+	Sqrt() *TensorStruct
+	Rsqrt() *TensorStruct
+	Sin() *TensorStruct
+	Cos() *TensorStruct
+	Tan() *TensorStruct
+	Tanh() *TensorStruct
+	Sigmoid() *TensorStruct
+	Sign() *TensorStruct
+	Floor() *TensorStruct
+	Ceil() *TensorStruct
+	Round() *TensorStruct
+	IsNaN() *TensorStruct
+	IsInf() *TensorStruct
+	NanToNum(nan, posInf, negInf float64) *TensorStruct
+	HasNaN() bool
+	Pow(other Operand) (*TensorStruct, error)
+	Clamp(lo, hi float64) (*TensorStruct, error)
+	Apply(fn func(float64) float64) *TensorStruct
+	ApplyBinary(other Operand, fn func(x, y float64) float64) (*TensorStruct, error)
+
+	Eq(other Operand) (*TensorStruct, error)
+	Ne(other Operand) (*TensorStruct, error)
+	Lt(other Operand) (*TensorStruct, error)
+	Le(other Operand) (*TensorStruct, error)
+	Gt(other Operand) (*TensorStruct, error)
+	Ge(other Operand) (*TensorStruct, error)
+	LogicalAnd(other Operand) (*TensorStruct, error)
+	LogicalOr(other Operand) (*TensorStruct, error)
+	LogicalXor(other Operand) (*TensorStruct, error)
+	LogicalNot() *TensorStruct
+	MaskedFill(mask Operand, value float64) (*TensorStruct, error)
+	MaskedSelect(mask Operand) (*TensorStruct, error)
+
+	IndexSelect(axis int, idx Operand) (*TensorStruct, error)
+	Gather(axis int, idx Operand) (*TensorStruct, error)
+	Scatter(axis int, idx Operand, src Operand) (*TensorStruct, error)
+	ScatterAdd(axis int, idx Operand, src Operand) (*TensorStruct, error)
+	Take(idx Operand) (*TensorStruct, error)
+	Put(idx Operand, values Operand) error
+	IndexWith(indices ...Operand) (*TensorStruct, error)
+
+	MatMul(other Operand) (*TensorStruct, error)
+	Dot(other Operand) (*TensorStruct, error)
+	Inner(other Operand) (*TensorStruct, error)
+	Outer(other Operand) *TensorStruct
+	MatVec(other Operand) (*TensorStruct, error)
+	Kron(other Operand) *TensorStruct
+
+	Sort(axis int, descending bool) (*TensorStruct, *TensorStruct, error)
+	ArgSort(axis int, descending bool) (*TensorStruct, error)
+	TopK(k int, axis int) (*TensorStruct, *TensorStruct, error)
+	Unique() (*TensorStruct, *TensorStruct, *TensorStruct)
+	SearchSorted(values Operand, right bool) (*TensorStruct, error)
+	Histogram(bins int) (*TensorStruct, *TensorStruct, error)
+
+	CumSum(axis int) (*TensorStruct, error)
+	CumProd(axis int) (*TensorStruct, error)
+	CumMax(axis int) (*TensorStruct, error)
+	CumMin(axis int) (*TensorStruct, error)
+	LogCumSumExp(axis int) (*TensorStruct, error)
+	Diff(n int, axis int) (*TensorStruct, error)
 }
 
 // NewView creates a new ViewStruct from a TensorStruct
@@ -61,50 +187,254 @@ func (v *ViewStruct) Stride() []int {
 	return v.stride
 }
 
-// Data returns the underlying data of the tensor
+// Offset returns the offset of the view's first element in the tensor's data
+func (v *ViewStruct) Offset() int {
+	return v.offset
+}
+
+// storage returns the data of the underlying tensor
+func (v *ViewStruct) storage() storage {
+	return v.tensor.data
+}
+
+// DType returns the dtype of the underlying tensor
+func (v *ViewStruct) DType() DType {
+	return v.tensor.DType()
+}
+
+// Data returns the view's elements in row-major order, a contiguous float64
+// view returns the underlying data, any other view returns a copy
 func (v *ViewStruct) Data() []float64 {
-	return v.tensor.Data()
+	return v.Contiguous().Data()
 }
 
 // String returns a string representation of the view
 func (v *ViewStruct) String() string {
+	data := v.Contiguous().Data()
+	dtype := v.DType()
 	switch len(v.shape) {
 	case 0:
-		return fmt.Sprintf("%.2f", v.tensor.data[0])
+		return formatValue(data[0], dtype)
 	case 1:
-		return fmt.Sprintf("[%s]", formatSlice(v.tensor.data))
+		return fmt.Sprintf("[%s]", formatSlice(data, dtype))
 	case 2:
 		rows := make([]string, v.shape[0])
 		for i := 0; i < v.shape[0]; i++ {
-			row := make([]string, v.shape[1])
-			for j := 0; j < v.shape[1]; j++ {
-				row[j] = fmt.Sprintf("%.2f", v.tensor.data[i*v.stride[0]+j*v.stride[1]])
-			}
-			rows[i] = "[" + strings.Join(row, " ") + "]"
+			start := i * v.shape[1]
+			end := start + v.shape[1]
+			rows[i] = "[" + formatSlice(data[start:end], dtype) + "]"
 		}
 		return "[\n " + strings.Join(rows, "\n ") + "\n]"
 	default:
-		return fmt.Sprintf("Tensor(shape=%v, data=[%s])", v.shape, formatSlice(v.tensor.data))
+		return fmt.Sprintf("Tensor(shape=%v, data=[%s])", v.shape, formatSlice(data, dtype))
+	}
+}
+
+// At returns the element at the given index of the view
+func (v *ViewStruct) At(idx ...int) (float64, error) {
+	// Convert the index to an offset
+	offset, err := offsetOf(v.shape, v.stride, idx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Return the element
+	return v.tensor.data.get(v.offset + offset), nil
+}
+
+// Set sets the element at the given index of the view, the change is visible
+// through the underlying tensor
+func (v *ViewStruct) Set(value float64, idx ...int) error {
+	// Convert the index to an offset
+	offset, err := offsetOf(v.shape, v.stride, idx)
+	if err != nil {
+		return err
+	}
+
+	// Set the element
+	v.tensor.data.set(v.offset+offset, value)
+	return nil
+}
+
+// AtFlat returns the element at the given row-major flat index of the view
+func (v *ViewStruct) AtFlat(idx int) (float64, error) {
+	// Convert the flat index to an offset
+	offset, err := flatOffsetOf(v.shape, v.stride, idx)
+	if err != nil {
+		return 0, err
+	}
+
+	// Return the element
+	return v.tensor.data.get(v.offset + offset), nil
+}
+
+// SetFlat sets the element at the given row-major flat index of the view
+func (v *ViewStruct) SetFlat(value float64, idx int) error {
+	// Convert the flat index to an offset
+	offset, err := flatOffsetOf(v.shape, v.stride, idx)
+	if err != nil {
+		return err
+	}
+
+	// Set the element
+	v.tensor.data.set(v.offset+offset, value)
+	return nil
+}
+
+// Item returns the only element of a view holding exactly one element
+func (v *ViewStruct) Item() (float64, error) {
+	// Check that there is exactly one element
+	if err := validItem(v.shape); err != nil {
+		return 0, err
 	}
+
+	// Return the element
+	return v.tensor.data.get(v.offset), nil
 }
 
-// View returns a view of the tensor with the given shape
+// View returns a view of the view's elements with the given shape
 func (v *ViewStruct) View(shape []int) (*ViewStruct, error) {
-	// Create a new view with the given shape
-	return NewView(v.tensor).Reshape(shape)
+	return v.Reshape(shape)
+}
+
+// reshapeStrides computes the strides that let data laid out with the given
+// shape and strides be read with a new shape, reporting false when the
+// layout cannot be expressed with strides and the data has to be copied
+func reshapeStrides(shape []int, stride []int, newShape []int) ([]int, bool) {
+	// An empty shape has no data to lay out
+	newStride := make([]int, len(newShape))
+	if shapeSize(shape) == 0 {
+		return computeStrides(newShape), true
+	}
+
+	// Drop the size 1 axes of the old shape, they don't affect the layout
+	oldShape := []int{}
+	oldStride := []int{}
+	for i, dim := range shape {
+		if dim != 1 {
+			oldShape = append(oldShape, dim)
+			oldStride = append(oldStride, stride[i])
+		}
+	}
+
+	// Match groups of old axes to groups of new axes with the same size
+	oi, oj := 0, 1
+	ni, nj := 0, 1
+	for ni < len(newShape) && oi < len(oldShape) {
+		// Grow whichever group is smaller until the sizes match
+		np := newShape[ni]
+		op := oldShape[oi]
+		for np != op {
+			if np < op {
+				np *= newShape[nj]
+				nj++
+			} else {
+				op *= oldShape[oj]
+				oj++
+			}
+		}
+
+		// The old axes in the group must be contiguous with each other
+		for k := oi; k < oj-1; k++ {
+			if oldStride[k] != oldShape[k+1]*oldStride[k+1] {
+				return nil, false
+			}
+		}
+
+		// Lay out the new axes in the group in row-major order
+		newStride[nj-1] = oldStride[oj-1]
+		for k := nj - 1; k > ni; k-- {
+			newStride[k-1] = newStride[k] * newShape[k]
+		}
+
+		// Move on to the next groups
+		ni = nj
+		nj++
+		oi = oj
+		oj++
+	}
+
+	// Any remaining new axes have size 1, give them a packed stride
+	for k := ni; k < len(newShape); k++ {
+		newStride[k] = 1
+	}
+
+	// Return the strides
+	return newStride, true
 }
 
-// Reshape reshapes the view to the given shape
+// Reshape reshapes the view to the given shape, returning a view of the same
+// data when the view's strides allow it and a view of a copy otherwise, one
+// dimension of the shape may be -1 to infer it from the size of the view
 func (v *ViewStruct) Reshape(shape []int) (*ViewStruct, error) {
+	// Infer a -1 dimension from the size of the view
+	shape, err := inferShape(shapeSize(v.shape), shape)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to reshape without copying
+	reshaped, err := v.ReshapeNoCopy(shape)
+	if err == nil || !validReshape(v.shape, shape) {
+		return reshaped, err
+	}
+
+	// Copy the elements into a packed tensor and view that instead
+	return NewView(v.Materialize()).ReshapeNoCopy(shape)
+}
+
+// ReshapeNoCopy reshapes the view to the given shape, returning an error if
+// the view's elements can't be reshaped without copying them
+func (v *ViewStruct) ReshapeNoCopy(shape []int) (*ViewStruct, error) {
+	// Infer a -1 dimension from the size of the view
+	shape, err := inferShape(shapeSize(v.shape), shape)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if the reshape is valid
 	if !validReshape(v.shape, shape) {
-		return nil, fmt.Errorf("invalid reshape")
+		return nil, fmt.Errorf("cannot reshape shape %v into shape %v", v.shape, shape)
+	}
+
+	// Compute the strides of the reshaped view
+	stride, ok := reshapeStrides(v.shape, v.stride, shape)
+	if !ok {
+		return nil, fmt.Errorf("cannot reshape view with shape %v and stride %v to %v without copying", v.shape, v.stride, shape)
 	}
 
 	// Create the view
 	return &ViewStruct{
 		shape:  shape,
-		stride: computeStrides(shape),
+		stride: stride,
+		offset: v.offset,
 		tensor: v.tensor,
 	}, nil
 }
+
+// Materialize copies the view's elements into a new packed tensor that
+// doesn't share data with the view
+func (v *ViewStruct) Materialize() *TensorStruct {
+	return newTensor(v.shape, gather(v.tensor.data, v.shape, v.stride, v.offset))
+}
+
+// Add adds another tensor, view or broadcast to this view
+func (v *ViewStruct) Add(other Operand) (*TensorStruct, error) {
+	return add(v, other)
+}
+
+// Sub subtracts another tensor, view or broadcast from this view
+func (v *ViewStruct) Sub(other Operand) (*TensorStruct, error) {
+	return sub(v, other)
+}
+
+// Mul multiplies this view by another tensor, view or broadcast
+func (v *ViewStruct) Mul(other Operand) (*TensorStruct, error) {
+	return mul(v, other)
+}
+
+// Div divides this view by another tensor, view or broadcast, dividing by zero
+// follows the current division policy
+func (v *ViewStruct) Div(other Operand) (*TensorStruct, error) {
+	return div(v, other)
+}
@@ -53,7 +53,10 @@ func TestView(t *testing.T) {
 		expectErr bool
 	}{
 		{"InvalidShape", []int{7}, true},
-		{"NegativeShape", []int{-1, 6}, true},
+		{"NegativeShape", []int{-2, 3}, true},
+		{"InferredShape", []int{-1, 6}, false},
+		{"TwoInferred", []int{-1, -1}, true},
+		{"UninferableShape", []int{-1, 4}, true},
 		{"EmptyShape", []int{}, true},
 		{"ValidShape", []int{2, 3}, false},
 		{"ValidReshape", []int{1, 6}, false},
@@ -128,3 +131,141 @@ func TestViewEdgeCases(t *testing.T) {
 		}
 	})
 }
+
+// TestViewElementAccess tests reading and writing elements through a view
+func TestViewElementAccess(t *testing.T) {
+	tensor := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+	view, err := tensor.View([]int{3, 2})
+	if err != nil {
+		t.Fatalf("Failed to create view: %v", err)
+	}
+
+	got, err := view.At(2, 0)
+	if err != nil {
+		t.Fatalf("At: expected no error, got %v", err)
+	}
+	checkEqual(t, "At", 5.0, got)
+
+	got, err = view.AtFlat(3)
+	if err != nil {
+		t.Fatalf("AtFlat: expected no error, got %v", err)
+	}
+	checkEqual(t, "AtFlat", 4.0, got)
+
+	if err := view.Set(10, 0, 1); err != nil {
+		t.Fatalf("Set: expected no error, got %v", err)
+	}
+	if err := view.SetFlat(20, 5); err != nil {
+		t.Fatalf("SetFlat: expected no error, got %v", err)
+	}
+	checkEqual(t, "Tensor Data", []float64{1, 10, 3, 4, 5, 20}, tensor.Data())
+
+	if _, err := view.At(0, 2); err == nil {
+		t.Error("At: expected out of bounds error, got nil")
+	}
+	if _, err := view.Item(); err == nil {
+		t.Error("Item: expected error for multi-element view, got nil")
+	}
+}
+
+// TestViewStrides tests that views read their elements through their strides
+func TestViewStrides(t *testing.T) {
+	tensor := mustNewTensor(t, []int{2, 3}, []float64{1, 2, 3, 4, 5, 6})
+
+	// Transposed views
+	transposed := tensor.Transpose()
+	checkEqual(t, "Transposed Data", []float64{1, 4, 2, 5, 3, 6}, transposed.Data())
+	checkEqual(t, "Transposed String", "[\n [1.00 4.00]\n [2.00 5.00]\n [3.00 6.00]\n]", transposed.String())
+
+	// Sliced views
+	column, _ := tensor.Slice(All(), Index(2))
+	checkEqual(t, "Column Data", []float64{3, 6}, column.Data())
+	checkEqual(t, "Column String", "[3.00 6.00]", column.String())
+
+	// Scalar views
+	element, _ := tensor.Slice(Index(1), Index(1))
+	checkEqual(t, "Element String", "5.00", element.String())
+
+	// Views of views keep the layout of the view
+	reversed, _ := tensor.Slice(All(), Step(End, End, -1))
+	flat, err := reversed.View([]int{6})
+	if err != nil {
+		t.Fatalf("Failed to view: %v", err)
+	}
+	checkEqual(t, "Reversed Data", []float64{3, 2, 1, 6, 5, 4}, flat.Data())
+}
+
+// TestReshapeStrides tests reshaping views with and without copying
+func TestReshapeStrides(t *testing.T) {
+	data := make([]float64, 24)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	tensor := mustNewTensor(t, []int{4, 6}, data)
+
+	// Splitting an axis of a transposed view doesn't need a copy
+	transposed := tensor.Transpose()
+	split, err := transposed.ReshapeNoCopy([]int{2, 3, 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Split Stride", []int{3, 1, 6}, split.Stride())
+	checkEqual(t, "Split Data", transposed.Data(), split.Data())
+
+	// Adding size 1 axes doesn't need a copy
+	padded, err := transposed.ReshapeNoCopy([]int{1, 6, 4, 1})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Padded Data", transposed.Data(), padded.Data())
+
+	// Flattening a transposed view needs a copy
+	if _, err := transposed.ReshapeNoCopy([]int{24}); err == nil {
+		t.Error("Expected error flattening a transposed view without copying")
+	}
+	flat, err := transposed.Reshape([]int{24})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Flat Stride", []int{1}, flat.Stride())
+	checkEqual(t, "Flat Data", transposed.Data(), flat.Data())
+
+	// The copy doesn't share data with the tensor
+	if err := flat.Set(100, 0); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+	checkEqual(t, "Tensor Unchanged", 0.0, data[0])
+
+	// Reshaping a contiguous slice shares data with the tensor
+	rows, _ := tensor.Slice(Range(1, 3))
+	reshaped, err := rows.Reshape([]int{3, 4})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	checkEqual(t, "Rows Offset", 6, reshaped.Offset())
+	if err := reshaped.Set(-1, 0, 0); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+	checkEqual(t, "Tensor Changed", -1.0, data[6])
+
+	// Invalid reshapes fail either way
+	if _, err := transposed.Reshape([]int{5}); err == nil {
+		t.Error("Expected error for invalid reshape")
+	}
+}
+
+// TestMaterialize tests copying a view into a new tensor
+func TestMaterialize(t *testing.T) {
+	tensor := mustNewTensor(t, []int{2, 2}, []float64{1, 2, 3, 4})
+	view, _ := tensor.View([]int{4})
+
+	packed := view.Materialize()
+	checkEqual(t, "Shape", []int{4}, packed.Shape())
+	checkEqual(t, "Data", []float64{1, 2, 3, 4}, packed.Data())
+
+	// The materialized tensor is independent of the view
+	if err := packed.Set(10, 0); err != nil {
+		t.Fatalf("Failed to set: %v", err)
+	}
+	checkEqual(t, "Tensor Data", []float64{1, 2, 3, 4}, tensor.Data())
+}